@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/hex"
 	"io/ioutil"
+	"log"
 	"os"
 	"os/signal"
 	"strconv"
@@ -15,18 +16,25 @@ import (
 	"github.com/fletaio/fleta/cmd/app"
 	"github.com/fletaio/fleta/cmd/closer"
 	"github.com/fletaio/fleta/cmd/config"
+	"github.com/fletaio/fleta/cmd/storetool"
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/common/key"
 	"github.com/fletaio/fleta/common/rlog"
 	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/badger_driver"
+	_ "github.com/fletaio/fleta/core/backend/bolt_driver"
 	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	_ "github.com/fletaio/fleta/core/backend/keydb_driver"
+	_ "github.com/fletaio/fleta/core/backend/leveldb_driver"
 	"github.com/fletaio/fleta/core/chain"
 	"github.com/fletaio/fleta/pof"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 )
@@ -45,9 +53,11 @@ type Config struct {
 	Port            int
 	APIPort         int
 	StoreRoot       string
+	Backend         string
 	RLogHost        string
 	RLogPath        string
 	UseRLog         bool
+	Consensus       pof.ConsensusConfig
 }
 
 func main() {
@@ -58,6 +68,20 @@ func main() {
 	if len(cfg.StoreRoot) == 0 {
 		cfg.StoreRoot = "./fdata"
 	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := storetool.RunMigrate(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "recompress" {
+		if err := storetool.RunRecompress(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
 	if len(cfg.RLogHost) > 0 && cfg.UseRLog {
 		if len(cfg.RLogPath) == 0 {
 			cfg.RLogPath = "./fdata_rlog"
@@ -139,11 +163,11 @@ func main() {
 	}()
 	defer cm.CloseAll()
 
-	MaxBlocksPerFormulator := uint32(10)
 	ChainID := uint8(0x01)
 	Symbol := "FLETA"
 	Usage := "Mainnet"
 	Version := uint16(0x0001)
+	KeyResetHeight := uint32(0xFFFFFFFF) // not scheduled yet
 	var InitGenesisHash hash.Hash256
 	if len(cfg.InitGenesisHash) > 0 {
 		InitGenesisHash = hash.MustParseHash(cfg.InitGenesisHash)
@@ -153,7 +177,7 @@ func main() {
 		InitHash = hash.MustParseHash(cfg.InitHash)
 	}
 
-	back, err := backend.Create("buntdb", cfg.StoreRoot+"/context")
+	back, _, err := backend.Open(cfg.StoreRoot, cfg.Backend)
 	if err != nil {
 		panic(err)
 	}
@@ -166,6 +190,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	st.SetKeyResetHeight(KeyResetHeight)
 	cm.Add("store", st)
 
 	if st.Height() > st.InitHeight() {
@@ -174,14 +199,18 @@ func main() {
 		}
 	}
 
-	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
+	cs := pof.NewConsensus(&cfg.Consensus, ObserverKeys)
 	app := app.NewFletaApp()
 	cn := chain.NewChain(cs, app, st)
 	cn.MustAddProcess(admin.NewAdmin(1))
 	cn.MustAddProcess(vault.NewVault(2))
-	cn.MustAddProcess(formulator.NewFormulator(3))
+	fp := formulator.NewFormulator(3)
+	fp.SetConsensusState(cs)
+	cn.MustAddProcess(fp)
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	if err := cn.Init(InitGenesisHash, InitHash, cfg.InitHeight, cfg.InitTimestamp); err != nil {
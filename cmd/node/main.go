@@ -3,29 +3,39 @@ package main
 import (
 	"encoding/hex"
 	"io/ioutil"
+	"log"
 	"os"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/fletaio/fleta/core/pile"
 
 	"github.com/fletaio/fleta/cmd/app"
 	"github.com/fletaio/fleta/cmd/closer"
 	"github.com/fletaio/fleta/cmd/config"
+	"github.com/fletaio/fleta/cmd/storetool"
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/common/key"
 	"github.com/fletaio/fleta/common/rlog"
 	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/badger_driver"
+	_ "github.com/fletaio/fleta/core/backend/bolt_driver"
 	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta/core/backend/keydb_driver"
+	_ "github.com/fletaio/fleta/core/backend/leveldb_driver"
 	"github.com/fletaio/fleta/core/chain"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/keydb"
 	"github.com/fletaio/fleta/pof"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 	"github.com/fletaio/fleta/service/p2p"
@@ -43,11 +53,38 @@ type Config struct {
 	Port            int
 	APIPort         int
 	StoreRoot       string
+	Backend         string
+	SyncBatchSize   int
+	SyncBatchTime   int
+	ReadOnly        bool
+	FollowTime      int
+	CacheSize       int
+	KeyDB           KeyDBConfig
 	RLogHost        string
 	RLogPath        string
 	UseRLog         bool
 }
 
+// KeyDBConfig is options of the keydb backend
+type KeyDBConfig struct {
+	SyncPolicy           string
+	AutoShrinkPercentage int
+	AutoShrinkMinSize    int
+	AutoShrinkDisabled   bool
+	TypedAccounts        bool
+}
+
+// chain parameters of the mainnet
+const (
+	ChainID = uint8(0x01)
+	Symbol  = "FLETA"
+	Usage   = "Mainnet"
+	Version = uint16(0x0001)
+
+	// KeyResetHeight is the activation height of the key reset of the snapshot commit (not scheduled yet)
+	KeyResetHeight = uint32(0xFFFFFFFF)
+)
+
 func main() {
 	var cfg Config
 	if err := config.LoadFile("./config.toml", &cfg); err != nil {
@@ -56,6 +93,27 @@ func main() {
 	if len(cfg.StoreRoot) == 0 {
 		cfg.StoreRoot = "./ndata"
 	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := storetool.RunMigrate(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "recompress" {
+		if err := storetool.RunRecompress(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		if err := runVerify(&cfg, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
 	if len(cfg.RLogHost) > 0 && cfg.UseRLog {
 		if len(cfg.RLogPath) == 0 {
 			cfg.RLogPath = "./ndata_rlog"
@@ -63,6 +121,12 @@ func main() {
 		rlog.SetRLogHost(cfg.RLogHost)
 		rlog.Enablelogger(cfg.RLogPath)
 	}
+	if cfg.ReadOnly {
+		if err := runReadOnly(&cfg); err != nil {
+			panic(err)
+		}
+		return
+	}
 
 	var ndkey key.Key
 	if len(cfg.NodeKeyHex) > 0 {
@@ -96,13 +160,9 @@ func main() {
 		}
 	}
 
-	ObserverKeys := []common.PublicHash{}
-	for _, k := range cfg.ObserverKeys {
-		pubhash, err := common.ParsePublicHash(k)
-		if err != nil {
-			panic(err)
-		}
-		ObserverKeys = append(ObserverKeys, pubhash)
+	ObserverKeys, err := parseObserverKeys(&cfg)
+	if err != nil {
+		panic(err)
 	}
 	SeedNodeMap := map[common.PublicHash]string{}
 	for k, netAddr := range cfg.SeedNodeMap {
@@ -126,24 +186,18 @@ func main() {
 	}()
 	defer cm.CloseAll()
 
-	MaxBlocksPerFormulator := uint32(10)
-	ChainID := uint8(0x01)
-	Symbol := "FLETA"
-	Usage := "Mainnet"
-	Version := uint16(0x0001)
-	var InitGenesisHash hash.Hash256
-	if len(cfg.InitGenesisHash) > 0 {
-		InitGenesisHash = hash.MustParseHash(cfg.InitGenesisHash)
-	}
-	var InitHash hash.Hash256
-	if len(cfg.InitHash) > 0 {
-		InitHash = hash.MustParseHash(cfg.InitHash)
-	}
+	InitGenesisHash, InitHash := parseInitHashes(&cfg)
 
-	back, err := backend.Create("buntdb", cfg.StoreRoot+"/context")
+	keydb_driver.SetTypedValues(cfg.KeyDB.TypedAccounts)
+	back, _, err := backend.Open(cfg.StoreRoot, cfg.Backend)
 	if err != nil {
 		panic(err)
 	}
+	if kb, is := back.(*keydb_driver.StoreBackendKeyDB); is {
+		if err := setupKeyDB(kb, &cfg.KeyDB); err != nil {
+			panic(err)
+		}
+	}
 	cdb, err := pile.Open(cfg.StoreRoot+"/chain", InitHash, cfg.InitHeight, cfg.InitTimestamp)
 	if err != nil {
 		panic(err)
@@ -153,6 +207,10 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	st.SetKeyResetHeight(KeyResetHeight)
+	if cfg.CacheSize != 0 {
+		st.SetCacheSize(int64(cfg.CacheSize) << 20)
+	}
 	cm.Add("store", st)
 
 	if st.Height() > st.InitHeight() {
@@ -161,14 +219,7 @@ func main() {
 		}
 	}
 
-	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
-	app := app.NewFletaApp()
-	cn := chain.NewChain(cs, app, st)
-	cn.MustAddProcess(admin.NewAdmin(1))
-	cn.MustAddProcess(vault.NewVault(2))
-	cn.MustAddProcess(formulator.NewFormulator(3))
-	cn.MustAddProcess(gateway.NewGateway(4))
-	cn.MustAddProcess(payment.NewPayment(5))
+	cn := newChain(st, ObserverKeys)
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	if err := cn.Init(InitGenesisHash, InitHash, cfg.InitHeight, cfg.InitTimestamp); err != nil {
@@ -177,6 +228,12 @@ func main() {
 	cm.RemoveAll()
 	cm.Add("chain", cn)
 
+	if cfg.SyncBatchSize > 0 {
+		if err := cn.SetBatchMode(cfg.SyncBatchSize, time.Duration(cfg.SyncBatchTime)*time.Millisecond); err != nil {
+			panic(err)
+		}
+	}
+
 	if err := st.IterBlockAfterContext(func(b *types.Block) error {
 		if cm.IsClosed() {
 			return chain.ErrStoreClosed
@@ -204,3 +261,63 @@ func main() {
 
 	cm.Wait()
 }
+
+func parseObserverKeys(cfg *Config) ([]common.PublicHash, error) {
+	ObserverKeys := []common.PublicHash{}
+	for _, k := range cfg.ObserverKeys {
+		pubhash, err := common.ParsePublicHash(k)
+		if err != nil {
+			return nil, err
+		}
+		ObserverKeys = append(ObserverKeys, pubhash)
+	}
+	return ObserverKeys, nil
+}
+
+func setupKeyDB(kb *keydb_driver.StoreBackendKeyDB, kcfg *KeyDBConfig) error {
+	SyncPolicy, err := keydb.ParseSyncPolicy(kcfg.SyncPolicy)
+	if err != nil {
+		return err
+	}
+	config := keydb.Config{
+		SyncPolicy:           SyncPolicy,
+		AutoShrinkPercentage: kcfg.AutoShrinkPercentage,
+		AutoShrinkMinSize:    kcfg.AutoShrinkMinSize,
+		AutoShrinkDisabled:   kcfg.AutoShrinkDisabled,
+	}
+	if config.AutoShrinkPercentage <= 0 {
+		config.AutoShrinkPercentage = 100
+	}
+	if config.AutoShrinkMinSize <= 0 {
+		config.AutoShrinkMinSize = 32 * 1024 * 1024
+	}
+	return kb.SetConfig(config)
+}
+
+func parseInitHashes(cfg *Config) (hash.Hash256, hash.Hash256) {
+	var InitGenesisHash hash.Hash256
+	if len(cfg.InitGenesisHash) > 0 {
+		InitGenesisHash = hash.MustParseHash(cfg.InitGenesisHash)
+	}
+	var InitHash hash.Hash256
+	if len(cfg.InitHash) > 0 {
+		InitHash = hash.MustParseHash(cfg.InitHash)
+	}
+	return InitGenesisHash, InitHash
+}
+
+func newChain(st *chain.Store, ObserverKeys []common.PublicHash) *chain.Chain {
+	cs := pof.NewConsensus(pof.DefaultConsensusConfig(), ObserverKeys)
+	app := app.NewFletaApp()
+	cn := chain.NewChain(cs, app, st)
+	cn.MustAddProcess(admin.NewAdmin(1))
+	cn.MustAddProcess(vault.NewVault(2))
+	fp := formulator.NewFormulator(3)
+	fp.SetConsensusState(cs)
+	cn.MustAddProcess(fp)
+	cn.MustAddProcess(gateway.NewGateway(4))
+	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
+	return cn
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fletaio/fleta/cmd/closer"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// runReadOnly serves the api of the store root which is written by the other node process on the same machine
+// it does not connect to peers and follows the height of the writer by refreshing the store
+func runReadOnly(cfg *Config) error {
+	ObserverKeys, err := parseObserverKeys(cfg)
+	if err != nil {
+		return err
+	}
+	InitGenesisHash, InitHash := parseInitHashes(cfg)
+
+	FollowTime := time.Duration(cfg.FollowTime) * time.Millisecond
+	if FollowTime <= 0 {
+		FollowTime = time.Second
+	}
+
+	cm := closer.NewManager()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	go func() {
+		<-sigc
+		cm.CloseAll()
+	}()
+	defer cm.CloseAll()
+
+	back, _, err := backend.OpenReadOnly(cfg.StoreRoot, cfg.Backend)
+	if err != nil {
+		return err
+	}
+	cdb, err := pile.OpenReadOnly(cfg.StoreRoot+"/chain", InitHash, cfg.InitHeight, cfg.InitTimestamp)
+	if err != nil {
+		back.Close()
+		return err
+	}
+	st, err := chain.NewReadOnlyStore(back, cdb, ChainID, Symbol, Usage, Version)
+	if err != nil {
+		back.Close()
+		cdb.Close()
+		return err
+	}
+	if cfg.CacheSize != 0 {
+		st.SetCacheSize(int64(cfg.CacheSize) << 20)
+	}
+	cm.Add("store", st)
+
+	cn := newChain(st, ObserverKeys)
+	as := apiserver.NewAPIServer()
+	cn.MustAddService(as)
+	if err := cn.Init(InitGenesisHash, InitHash, cfg.InitHeight, cfg.InitTimestamp); err != nil {
+		return err
+	}
+	cm.RemoveAll()
+	cm.Add("chain", cn)
+
+	go func() {
+		ticker := time.NewTicker(FollowTime)
+		defer ticker.Stop()
+
+		LastHeight := st.Height()
+		for !cm.IsClosed() {
+			<-ticker.C
+			Height, err := st.Refresh()
+			if err != nil {
+				if err == chain.ErrStoreClosed {
+					return
+				}
+				log.Println("Store failed to refresh", err)
+				continue
+			}
+			if Height != LastHeight {
+				// memory states of the consensus and processes are loaded again to follow the rank table and observer keys
+				if err := cn.Reload(); err != nil {
+					if err == chain.ErrChainClosed {
+						return
+					}
+					log.Println("Chain failed to reload", err)
+					continue
+				}
+				log.Println("Store followed", LastHeight, "->", Height)
+				LastHeight = Height
+			}
+		}
+	}()
+	go as.Run(":" + strconv.Itoa(cfg.APIPort))
+
+	cm.Wait()
+	return nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/pile"
+)
+
+// verifyResult is the result of the offline integrity check
+type verifyResult struct {
+	PileHeight      uint32
+	StateHeight     uint32
+	LastValidHeight uint32
+	ReplayedHeight  uint32
+	DivergentHeight uint32
+	NeedTruncate    bool
+	NeedRebuild     bool
+}
+
+// runVerify checks the pile and the context DB offline and repairs them when it is requested
+// usage: node verify [-repair] [-y]
+func runVerify(cfg *Config, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "truncate the pile and rebuild the context DB from the valid blocks")
+	yes := flags.Bool("y", false, "repair without the confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ObserverKeys, err := parseObserverKeys(cfg)
+	if err != nil {
+		return err
+	}
+	InitGenesisHash, InitHash := parseInitHashes(cfg)
+
+	ChainPath := filepath.Join(cfg.StoreRoot, "chain")
+	ScratchPath := filepath.Join(cfg.StoreRoot, "verify")
+
+	// pile files
+	statuses, err := pile.CheckDir(ChainPath)
+	if err != nil {
+		return err
+	}
+	for _, fs := range statuses {
+		if fs.IsConsistent() {
+			log.Println("[pile]", filepath.Base(fs.Path), "begin", fs.BeginHeight, "head", fs.HeadHeight, "ok")
+		} else {
+			log.Println("[pile]", filepath.Base(fs.Path), "begin", fs.BeginHeight, "head", fs.HeadHeight, fs.HeadHeightCheckA, fs.HeadHeightCheckB, "inconsistent", fs.Err)
+		}
+	}
+
+	back, loc, err := backend.Open(cfg.StoreRoot, cfg.Backend)
+	if err != nil {
+		return err
+	}
+	ContextPath := filepath.Join(cfg.StoreRoot, loc.Path)
+	isBackClosed := false
+	defer func() {
+		if !isBackClosed {
+			back.Close()
+		}
+	}()
+	cdb, err := pile.Open(ChainPath, InitHash, cfg.InitHeight, cfg.InitTimestamp)
+	if err != nil {
+		return err
+	}
+	defer cdb.Close()
+
+	res := &verifyResult{
+		PileHeight: cdb.Height(),
+	}
+	if res.StateHeight, err = chain.StateHeight(back); err != nil {
+		if err != backend.ErrNotExistKey {
+			return err
+		}
+		log.Println("[state] not initialized")
+		return nil
+	}
+	log.Println("[state] height", res.StateHeight, "[pile] height", res.PileHeight)
+
+	// blocks in the pile
+	res.LastValidHeight, err = chain.CheckPileBlocks(cdb, ChainID, 0, res.PileHeight)
+	if err != nil {
+		log.Println("[pile] invalid block at", res.LastValidHeight+1, err)
+		res.DivergentHeight = res.LastValidHeight + 1
+		res.NeedTruncate = true
+	} else {
+		log.Println("[pile] blocks are valid until", res.LastValidHeight)
+	}
+	if res.StateHeight > res.LastValidHeight {
+		log.Println("[state] state is over the valid blocks", res.StateHeight, res.LastValidHeight)
+		res.NeedRebuild = true
+	}
+
+	// replay blocks to the scratch backend
+	os.RemoveAll(ScratchPath)
+	defer os.RemoveAll(ScratchPath)
+	var scn *chain.Chain
+	if cfg.InitHeight > 0 {
+		log.Println("[replay] skipped because the chain is initialized from the height", cfg.InitHeight)
+	} else {
+		sback, err := backend.Create(loc.Driver, filepath.Join(ScratchPath, "context"))
+		if err != nil {
+			return err
+		}
+		scdb, err := pile.Open(filepath.Join(ScratchPath, "chain"), InitHash, cfg.InitHeight, cfg.InitTimestamp)
+		if err != nil {
+			sback.Close()
+			return err
+		}
+		sst, err := chain.NewStore(sback, scdb, ChainID, Symbol, Usage, Version)
+		if err != nil {
+			sback.Close()
+			scdb.Close()
+			return err
+		}
+		sst.SetKeyResetHeight(KeyResetHeight)
+		scn = newChain(sst, ObserverKeys)
+		defer scn.Close()
+		if err := scn.Init(InitGenesisHash, InitHash, cfg.InitHeight, cfg.InitTimestamp); err != nil {
+			return err
+		}
+
+		Target := res.StateHeight
+		if Target > res.LastValidHeight {
+			Target = res.LastValidHeight
+		}
+		start := time.Now()
+		for h := scn.Provider().Height() + 1; h <= Target; h++ {
+			b, err := chain.LoadPileBlock(cdb, h)
+			if err != nil {
+				return err
+			}
+			if err := scn.ConnectBlock(b, nil); err != nil {
+				log.Println("[replay] failed at", h, err)
+				res.DivergentHeight = h
+				res.NeedTruncate = true
+				res.NeedRebuild = true
+				break
+			}
+			if h%10000 == 0 {
+				log.Println("[replay]", h, "/", Target, time.Now().Sub(start))
+			}
+		}
+		res.ReplayedHeight = scn.Provider().Height()
+		log.Println("[replay] replayed until", res.ReplayedHeight)
+
+		if res.ReplayedHeight == res.StateHeight {
+			if key, err := chain.CompareBackends(back, sback); err != nil {
+				if err != chain.ErrStateMismatch {
+					return err
+				}
+				log.Println("[state] diverges at", res.StateHeight, "key", hex.EncodeToString(key))
+				if res.DivergentHeight == 0 {
+					res.DivergentHeight = res.StateHeight
+				}
+				res.NeedRebuild = true
+			} else {
+				log.Println("[state] same with the replayed state")
+			}
+		}
+	}
+
+	if !res.NeedTruncate && !res.NeedRebuild {
+		log.Println("[verify] no problem is found")
+		return nil
+	}
+	log.Println("[verify] first divergent height", res.DivergentHeight)
+	if !*repair {
+		log.Println("[verify] run with -repair to truncate the pile and rebuild the context DB")
+		return chain.ErrStateMismatch
+	}
+
+	TruncateHeight := res.LastValidHeight
+	if res.ReplayedHeight > 0 && res.ReplayedHeight < TruncateHeight && res.DivergentHeight == res.ReplayedHeight+1 {
+		TruncateHeight = res.ReplayedHeight
+	}
+	if res.NeedRebuild && scn == nil {
+		log.Println("[repair] the context DB cannot be rebuilt without the replay")
+		res.NeedRebuild = false
+	}
+	if !*yes && !confirm("truncate the pile to "+strconv.FormatUint(uint64(TruncateHeight), 10)+" and rebuild the context DB") {
+		return nil
+	}
+	if res.NeedTruncate && TruncateHeight < res.PileHeight {
+		if err := cdb.Truncate(TruncateHeight); err != nil {
+			return err
+		}
+		log.Println("[repair] the pile is truncated to", TruncateHeight)
+	}
+	if res.NeedRebuild {
+		scn.Close()
+		back.Close()
+		isBackClosed = true
+		BrokenPath := ContextPath + ".broken." + strconv.FormatInt(time.Now().Unix(), 10)
+		if err := os.Rename(ContextPath, BrokenPath); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(ScratchPath, "context"), ContextPath); err != nil {
+			return err
+		}
+		log.Println("[repair] the context DB is rebuilt until", res.ReplayedHeight, "and the previous one is moved to", BrokenPath)
+	}
+	return nil
+}
+
+func confirm(msg string) bool {
+	log.Println(msg + "? [y/N]")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(line)) == "y"
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/hex"
+	"log"
 	"os"
 	"os/signal"
 	"strconv"
@@ -14,18 +15,25 @@ import (
 	"github.com/fletaio/fleta/cmd/app"
 	"github.com/fletaio/fleta/cmd/closer"
 	"github.com/fletaio/fleta/cmd/config"
+	"github.com/fletaio/fleta/cmd/storetool"
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/common/key"
 	"github.com/fletaio/fleta/common/rlog"
 	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/badger_driver"
+	_ "github.com/fletaio/fleta/core/backend/bolt_driver"
 	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	_ "github.com/fletaio/fleta/core/backend/keydb_driver"
+	_ "github.com/fletaio/fleta/core/backend/leveldb_driver"
 	"github.com/fletaio/fleta/core/chain"
 	"github.com/fletaio/fleta/pof"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 )
@@ -33,6 +41,7 @@ import (
 // Config is a configuration for the cmd
 type Config struct {
 	ObserverKeyMap  map[string]string
+	NetAddressMap   map[string]string
 	KeyHex          string
 	InitGenesisHash string
 	InitHash        string
@@ -42,9 +51,11 @@ type Config struct {
 	FormulatorPort  int
 	APIPort         int
 	StoreRoot       string
+	Backend         string
 	RLogHost        string
 	RLogPath        string
 	UseRLog         bool
+	Consensus       pof.ConsensusConfig
 }
 
 func main() {
@@ -55,6 +66,20 @@ func main() {
 	if len(cfg.StoreRoot) == 0 {
 		cfg.StoreRoot = "./odata"
 	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := storetool.RunMigrate(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "recompress" {
+		if err := storetool.RunRecompress(cfg.StoreRoot, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
 	if len(cfg.RLogHost) > 0 && cfg.UseRLog {
 		if len(cfg.RLogPath) == 0 {
 			cfg.RLogPath = "./odata_rlog"
@@ -82,6 +107,14 @@ func main() {
 		NetAddressMap[pubhash] = netAddr
 		ObserverKeys = append(ObserverKeys, pubhash)
 	}
+	// observers that are added on the chain are not genesis observers so they only have net addresses
+	for k, netAddr := range cfg.NetAddressMap {
+		pubhash, err := common.ParsePublicHash(k)
+		if err != nil {
+			panic(err)
+		}
+		NetAddressMap[pubhash] = netAddr
+	}
 
 	cm := closer.NewManager()
 	sigc := make(chan os.Signal, 1)
@@ -96,11 +129,11 @@ func main() {
 	}()
 	defer cm.CloseAll()
 
-	MaxBlocksPerFormulator := uint32(10)
 	ChainID := uint8(0x01)
 	Symbol := "FLETA"
 	Usage := "Mainnet"
 	Version := uint16(0x0001)
+	KeyResetHeight := uint32(0xFFFFFFFF) // not scheduled yet
 	var InitGenesisHash hash.Hash256
 	if len(cfg.InitGenesisHash) > 0 {
 		InitGenesisHash = hash.MustParseHash(cfg.InitGenesisHash)
@@ -110,7 +143,7 @@ func main() {
 		InitHash = hash.MustParseHash(cfg.InitHash)
 	}
 
-	back, err := backend.Create("buntdb", cfg.StoreRoot+"/context")
+	back, _, err := backend.Open(cfg.StoreRoot, cfg.Backend)
 	if err != nil {
 		panic(err)
 	}
@@ -123,6 +156,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	st.SetKeyResetHeight(KeyResetHeight)
 	cm.Add("store", st)
 
 	if st.Height() > st.InitHeight() {
@@ -131,14 +165,18 @@ func main() {
 		}
 	}
 
-	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
+	cs := pof.NewConsensus(&cfg.Consensus, ObserverKeys)
 	app := app.NewFletaApp()
 	cn := chain.NewChain(cs, app, st)
 	cn.MustAddProcess(admin.NewAdmin(1))
 	cn.MustAddProcess(vault.NewVault(2))
-	cn.MustAddProcess(formulator.NewFormulator(3))
+	fp := formulator.NewFormulator(3)
+	fp.SetConsensusState(cs)
+	cn.MustAddProcess(fp)
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	if err := cn.Init(InitGenesisHash, InitHash, cfg.InitHeight, cfg.InitTimestamp); err != nil {
@@ -0,0 +1,29 @@
+package storetool
+
+import (
+	"flag"
+	"log"
+
+	"github.com/fletaio/fleta/core/backend"
+)
+
+// RunMigrate moves the context DB of the store root to another driver
+// usage: migrate [-batch size] <driver>
+func RunMigrate(StoreRoot string, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	batch := flags.Int("batch", backend.DefaultBatchSize, "number of keys in a batch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return backend.ErrNotExistDriver
+	}
+	loc, err := backend.Migrate(StoreRoot, flags.Arg(0), *batch)
+	if err != nil {
+		return err
+	}
+	log.Println("The context DB is switched to", loc.Driver, "at", loc.Path)
+	log.Println("Set Backend = \"" + loc.Driver + "\" in config.toml and remove the previous context DB after checking the node")
+	return nil
+}
@@ -0,0 +1,44 @@
+package storetool
+
+import (
+	"flag"
+	"log"
+	"path/filepath"
+
+	"github.com/fletaio/fleta/core/pile"
+)
+
+// RunRecompress rewrites pile files of the store root by the codec
+// the node should be stopped before the recompression
+// usage: recompress [-codec snappy|gzip|none]
+func RunRecompress(StoreRoot string, args []string) error {
+	flags := flag.NewFlagSet("recompress", flag.ExitOnError)
+	name := flags.String("codec", pile.CodecName(pile.DefaultCodec), "codec of entries (snappy, gzip, none)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	Codec, err := pile.CodecByName(*name)
+	if err != nil {
+		return err
+	}
+	statuses, err := pile.CheckDir(filepath.Join(StoreRoot, "chain"))
+	if err != nil {
+		return err
+	}
+	var OldSize, NewSize int64
+	for _, fs := range statuses {
+		if !fs.IsConsistent() {
+			log.Println("[recompress]", filepath.Base(fs.Path), "is inconsistent", fs.Err)
+			return pile.ErrHeightCrashed
+		}
+		res, err := pile.Recompress(fs.Path, Codec)
+		if err != nil {
+			return err
+		}
+		log.Println("[recompress]", filepath.Base(res.Path), res.Count, "blocks", res.OldSize, "->", res.NewSize, "bytes")
+		OldSize += res.OldSize
+		NewSize += res.NewSize
+	}
+	log.Println("[recompress] all pile files are recompressed by", *name, OldSize, "->", NewSize, "bytes")
+	return nil
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 	"github.com/fletaio/fleta/service/p2p"
@@ -123,11 +125,11 @@ func main() {
 	}()
 	defer cm.CloseAll()
 
-	MaxBlocksPerFormulator := uint32(10)
 	ChainID := uint8(0x01)
 	Symbol := "FLETA"
 	Usage := "Mainnet"
 	Version := uint16(0x0001)
+	KeyResetHeight := uint32(0xFFFFFFFF) // not scheduled yet
 
 	back, err := backend.Create("buntdb", cfg.StoreRoot+"/context")
 	if err != nil {
@@ -142,6 +144,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	st.SetKeyResetHeight(KeyResetHeight)
 	cm.Add("store", st)
 
 	if st.Height() > 0 {
@@ -150,16 +153,19 @@ func main() {
 		}
 	}
 
-	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
+	cs := pof.NewConsensus(pof.DefaultConsensusConfig(), ObserverKeys)
 	app := app.NewFletaApp()
 	cn := chain.NewChain(cs, app, st)
 	cn.MustAddProcess(admin.NewAdmin(1))
 	vp := vault.NewVault(2)
 	cn.MustAddProcess(vp)
 	fp := formulator.NewFormulator(3)
+	fp.SetConsensusState(cs)
 	cn.MustAddProcess(fp)
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	keyStore, err := backend.Create("buntdb", cfg.StoreRoot+"/keystore")
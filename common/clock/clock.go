@@ -0,0 +1,48 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock provides the current time and timers
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer sends the time to the channel when it is expired
+type Timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// System is the clock that uses the system time
+var System Clock = &systemClock{}
+
+type systemClock struct{}
+
+// Now returns the current system time
+func (c *systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses the current goroutine for the duration
+func (c *systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// NewTimer returns a timer that expires after the duration
+func (c *systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{Timer: time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+// C returns the channel of the timer
+func (t *systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
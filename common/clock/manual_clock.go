@@ -0,0 +1,121 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// ManualClock is the clock that moves only when it is advanced
+type ManualClock struct {
+	sync.Mutex
+	now    time.Time
+	timers map[*manualTimer]bool
+}
+
+// NewManualClock returns a ManualClock which starts from the time
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{
+		now:    start,
+		timers: map[*manualTimer]bool{},
+	}
+}
+
+// Now returns the current time of the clock
+func (c *ManualClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+// Sleep pauses the current goroutine until the clock is advanced by the duration
+func (c *ManualClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-c.NewTimer(d).C()
+}
+
+// NewTimer returns a timer that expires when the clock is advanced by the duration
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	t := &manualTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward and fires expired timers
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	expired := []*manualTimer{}
+	for t := range c.timers {
+		if !t.deadline.After(now) {
+			expired = append(expired, t)
+			delete(c.timers, t)
+		}
+	}
+	c.Unlock()
+
+	for _, t := range expired {
+		select {
+		case t.ch <- now:
+		default:
+		}
+	}
+}
+
+// TimerCount returns the number of the pending timers
+func (c *ManualClock) TimerCount() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.timers)
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	ch       chan time.Time
+	deadline time.Time
+}
+
+// C returns the channel of the timer
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Reset changes the timer to expire after the duration
+func (t *manualTimer) Reset(d time.Duration) bool {
+	t.clock.Lock()
+	_, active := t.clock.timers[t]
+	t.deadline = t.clock.now.Add(d)
+	fire := d <= 0
+	if fire {
+		delete(t.clock.timers, t)
+	} else {
+		t.clock.timers[t] = true
+	}
+	now := t.clock.now
+	t.clock.Unlock()
+
+	if fire {
+		select {
+		case t.ch <- now:
+		default:
+		}
+	}
+	return active
+}
+
+// Stop prevents the timer from firing
+func (t *manualTimer) Stop() bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+
+	_, active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	return active
+}
@@ -0,0 +1,55 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := NewManualClock(start)
+
+	timer := c.NewTimer(100 * time.Millisecond)
+	c.Advance(99 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer is fired before the deadline")
+	default:
+	}
+	c.Advance(1 * time.Millisecond)
+	select {
+	case now := <-timer.C():
+		if !now.Equal(start.Add(100 * time.Millisecond)) {
+			t.Fatal("invalid fired time", now)
+		}
+	default:
+		t.Fatal("timer is not fired at the deadline")
+	}
+
+	if timer.Reset(50 * time.Millisecond) {
+		t.Fatal("expired timer should not be active")
+	}
+	if !timer.Stop() {
+		t.Fatal("reset timer should be active")
+	}
+	c.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer is fired")
+	default:
+	}
+	if c.TimerCount() != 0 {
+		t.Fatal("timer is remained", c.TimerCount())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Second)
+		close(done)
+	}()
+	for c.TimerCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Advance(time.Second)
+	<-done
+}
@@ -0,0 +1,414 @@
+package backendtest
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta/core/backend"
+)
+
+var errRollback = errors.New("rollback")
+
+// Run runs the conformance suite of the driver which is registered by backend.RegisterDriver
+// Persistent should be true when the driver keeps the data after Close
+func Run(t *testing.T, Driver string, Persistent bool) {
+	dir, err := ioutil.TempDir("", "fleta_backendtest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db backend.StoreBackend)
+	}{
+		{"MissingKey", testMissingKey},
+		{"SetGetDelete", testSetGetDelete},
+		{"IterateOrder", testIterateOrder},
+		{"IterateRange", testIterateRange},
+		{"IterateError", testIterateError},
+		{"UpdateVisibility", testUpdateVisibility},
+		{"Rollback", testRollback},
+		{"ConcurrentView", testConcurrentView},
+	}
+	for i, v := range tests {
+		path := filepath.Join(dir, Driver+"_"+v.name)
+		db, err := backend.Create(Driver, path)
+		if err != nil {
+			t.Fatal(Driver, i, err)
+		}
+		t.Run(v.name, func(t *testing.T) {
+			v.fn(t, db)
+		})
+		db.Close()
+	}
+	t.Run("Close", func(t *testing.T) {
+		testClose(t, Driver, filepath.Join(dir, Driver+"_Close"), Persistent)
+	})
+}
+
+func setKeys(db backend.StoreBackend, keys []string) error {
+	return db.Update(func(txn backend.StoreWriter) error {
+		for _, k := range keys {
+			if err := txn.Set([]byte(k), []byte("v"+k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func getValue(db backend.StoreBackend, key string) ([]byte, error) {
+	var value []byte
+	if err := db.View(func(txn backend.StoreReader) error {
+		v, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		value = v
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func listKeys(db backend.StoreBackend, opt *backend.IterateOption) (string, error) {
+	list := []string{}
+	if err := db.View(func(txn backend.StoreReader) error {
+		return txn.IterateRange(opt, func(key []byte, value []byte) error {
+			if !bytes.Equal(value, []byte("v"+string(key))) {
+				return errors.New("invalid value of " + string(key))
+			}
+			list = append(list, string(key))
+			return nil
+		})
+	}); err != nil {
+		return "", err
+	}
+	return strings.Join(list, ","), nil
+}
+
+func testMissingKey(t *testing.T, db backend.StoreBackend) {
+	if _, err := getValue(db, "missing"); err != backend.ErrNotExistKey {
+		t.Fatal("Get of the missing key should return ErrNotExistKey but", err)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if _, err := txn.Get([]byte("missing")); err != backend.ErrNotExistKey {
+			return errors.New("Get in Update should return ErrNotExistKey")
+		}
+		return txn.Delete([]byte("missing"))
+	}); err != nil {
+		t.Fatal("Delete of the missing key should not return an error but", err)
+	}
+	count := 0
+	if err := db.View(func(txn backend.StoreReader) error {
+		return txn.Iterate([]byte("missing"), func(key []byte, value []byte) error {
+			count++
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatal("Iterate of the missing prefix should not call the callback")
+	}
+}
+
+func testSetGetDelete(t *testing.T, db backend.StoreBackend) {
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if err := txn.Set([]byte("key"), []byte("value1")); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte("key"), []byte("value2")); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte("empty"), []byte{}); err != nil {
+			return err
+		}
+		return txn.Set([]byte{0, 0xFF, 0}, []byte{0xFF, 0, 0xFF})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := getValue(db, "key"); err != nil {
+		t.Fatal(err)
+	} else if string(v) != "value2" {
+		t.Fatal("overwritten value is not matched", string(v))
+	}
+	if v, err := getValue(db, "empty"); err != nil {
+		t.Fatal("empty value should be stored but", err)
+	} else if len(v) != 0 {
+		t.Fatal("empty value is not matched", v)
+	}
+	if v, err := getValue(db, string([]byte{0, 0xFF, 0})); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, []byte{0xFF, 0, 0xFF}) {
+		t.Fatal("binary value is not matched", v)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if err := txn.Delete([]byte("key")); err != nil {
+			return err
+		}
+		if _, err := txn.Get([]byte("key")); err != backend.ErrNotExistKey {
+			return errors.New("deleted key should not be found in the same Update")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getValue(db, "key"); err != backend.ErrNotExistKey {
+		t.Fatal("deleted key should return ErrNotExistKey but", err)
+	}
+}
+
+func testIterateOrder(t *testing.T, db backend.StoreBackend) {
+	// inserted out of the order to check the bytewise order of the iteration
+	keys := []string{"b2", "a", "c", "b1", "b\xff", "b", "b10", "ba"}
+	if err := setKeys(db, keys); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{"", "a,b,b1,b10,b2,ba,b\xff,c"},
+		{"b", "b,b1,b10,b2,ba,b\xff"},
+		{"b1", "b1,b10"},
+		{"b\xff", "b\xff"},
+		{"d", ""},
+	}
+	for i, v := range tests {
+		list := []string{}
+		if err := db.View(func(txn backend.StoreReader) error {
+			return txn.Iterate([]byte(v.prefix), func(key []byte, value []byte) error {
+				list = append(list, string(key))
+				return nil
+			})
+		}); err != nil {
+			t.Fatal(i, err)
+		}
+		if result := strings.Join(list, ","); result != v.expected {
+			t.Fatal(i, "expected", v.expected, "but", result)
+		}
+	}
+}
+
+func testIterateRange(t *testing.T, db backend.StoreBackend) {
+	if err := setKeys(db, []string{"a", "b1", "b2", "b3", "b4", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		opt      *backend.IterateOption
+		expected string
+	}{
+		{&backend.IterateOption{}, "a,b1,b2,b3,b4,c"},
+		{&backend.IterateOption{Prefix: []byte("b")}, "b1,b2,b3,b4"},
+		{&backend.IterateOption{Prefix: []byte("b"), Limit: 2}, "b1,b2"},
+		{&backend.IterateOption{Prefix: []byte("b"), From: []byte("b2")}, "b2,b3,b4"},
+		{&backend.IterateOption{Prefix: []byte("b"), From: []byte("b25"), Limit: 1}, "b3"},
+		{&backend.IterateOption{Prefix: []byte("b"), Reverse: true}, "b4,b3,b2,b1"},
+		{&backend.IterateOption{Prefix: []byte("b"), Reverse: true, Limit: 3}, "b4,b3,b2"},
+		{&backend.IterateOption{Prefix: []byte("b"), From: []byte("b3"), Reverse: true}, "b3,b2,b1"},
+		{&backend.IterateOption{Prefix: []byte("b"), From: []byte("b25"), Reverse: true}, "b2,b1"},
+		{&backend.IterateOption{Prefix: []byte("b"), From: []byte("z"), Reverse: true, Limit: 1}, "b4"},
+		{&backend.IterateOption{Reverse: true}, "c,b4,b3,b2,b1,a"},
+		{&backend.IterateOption{Prefix: []byte("d")}, ""},
+		{&backend.IterateOption{Prefix: []byte("d"), Reverse: true}, ""},
+	}
+	for i, v := range tests {
+		if result, err := listKeys(db, v.opt); err != nil {
+			t.Fatal(i, err)
+		} else if result != v.expected {
+			t.Fatal(i, "expected", v.expected, "but", result)
+		}
+	}
+}
+
+func testIterateError(t *testing.T, db backend.StoreBackend) {
+	if err := setKeys(db, []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	if err := db.View(func(txn backend.StoreReader) error {
+		return txn.Iterate([]byte{}, func(key []byte, value []byte) error {
+			count++
+			return errRollback
+		})
+	}); err != errRollback {
+		t.Fatal("the error of the callback should be returned but", err)
+	}
+	if count != 1 {
+		t.Fatal("the iteration should be stopped at the error", count)
+	}
+	count = 0
+	if err := db.View(func(txn backend.StoreReader) error {
+		return txn.IterateRange(&backend.IterateOption{}, func(key []byte, value []byte) error {
+			count++
+			return backend.ErrStopIteration
+		})
+	}); err != nil {
+		t.Fatal("ErrStopIteration should stop the iteration without an error but", err)
+	}
+	if count != 1 {
+		t.Fatal("the iteration should be stopped at ErrStopIteration", count)
+	}
+}
+
+func testUpdateVisibility(t *testing.T, db backend.StoreBackend) {
+	if err := setKeys(db, []string{"a", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if err := txn.Set([]byte("b"), []byte("vb")); err != nil {
+			return err
+		}
+		if err := txn.Delete([]byte("c")); err != nil {
+			return err
+		}
+		if v, err := txn.Get([]byte("b")); err != nil {
+			return err
+		} else if string(v) != "vb" {
+			return errors.New("written value should be visible in the same Update")
+		}
+		list := []string{}
+		if err := txn.Iterate([]byte{}, func(key []byte, value []byte) error {
+			list = append(list, string(key))
+			return nil
+		}); err != nil {
+			return err
+		}
+		if result := strings.Join(list, ","); result != "a,b" {
+			return errors.New("iteration in the same Update is not matched: " + result)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testRollback(t *testing.T, db backend.StoreBackend) {
+	if err := setKeys(db, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if err := txn.Set([]byte("a"), []byte("changed")); err != nil {
+			return err
+		}
+		if err := txn.Delete([]byte("b")); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte("c"), []byte("vc")); err != nil {
+			return err
+		}
+		return errRollback
+	}); err != errRollback {
+		t.Fatal("the error of Update should be returned but", err)
+	}
+	if result, err := listKeys(db, &backend.IterateOption{}); err != nil {
+		t.Fatal("changes of the failed Update should be rolled back", err)
+	} else if result != "a,b" {
+		t.Fatal("changes of the failed Update should be rolled back", result)
+	}
+}
+
+func testConcurrentView(t *testing.T, db backend.StoreBackend) {
+	if err := setKeys(db, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	viewed := make(chan error, 1)
+	updated := make(chan error, 1)
+	go func() {
+		updated <- db.Update(func(txn backend.StoreWriter) error {
+			if err := txn.Set([]byte("a"), []byte("new")); err != nil {
+				return err
+			}
+			close(started)
+			// the View can be blocked until the end of the Update
+			select {
+			case err := <-viewed:
+				viewed <- err
+			case <-time.After(100 * time.Millisecond):
+			}
+			return txn.Set([]byte("b"), []byte("new"))
+		})
+	}()
+	<-started
+	go func() {
+		var a, b []byte
+		viewed <- db.View(func(txn backend.StoreReader) error {
+			var err error
+			if a, err = txn.Get([]byte("a")); err != nil {
+				return err
+			}
+			if b, err = txn.Get([]byte("b")); err != nil {
+				return err
+			}
+			if !bytes.Equal(a, b) && !(string(a) == "va" && string(b) == "vb") {
+				return errors.New("View should not see the partial Update: " + string(a) + "," + string(b))
+			}
+			return nil
+		})
+	}()
+	select {
+	case err := <-updated:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Update is not finished")
+	}
+	select {
+	case err := <-viewed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("View is not finished")
+	}
+	if v, err := getValue(db, "b"); err != nil {
+		t.Fatal(err)
+	} else if string(v) != "new" {
+		t.Fatal("Update is not committed", string(v))
+	}
+}
+
+func testClose(t *testing.T, Driver string, path string, Persistent bool) {
+	db, err := backend.Create(Driver, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setKeys(db, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	if err := db.View(func(txn backend.StoreReader) error {
+		return nil
+	}); err == nil {
+		t.Fatal("View after Close should return an error")
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		return nil
+	}); err == nil {
+		t.Fatal("Update after Close should return an error")
+	}
+	if !Persistent {
+		return
+	}
+	db, err = backend.Create(Driver, path)
+	if err != nil {
+		t.Fatal("reopen", err)
+	}
+	defer db.Close()
+	if result, err := listKeys(db, &backend.IterateOption{}); err != nil {
+		t.Fatal(err)
+	} else if result != "a,b" {
+		t.Fatal("data should be remained after Close", result)
+	}
+}
@@ -0,0 +1,77 @@
+package backendtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/core/backend"
+)
+
+// RunReadOnly checks the read-only backend which follows updates of the writer on the same path
+// it is skipped when the driver does not support the read-only open
+func RunReadOnly(t *testing.T, Driver string) {
+	dir, err := ioutil.TempDir("", "fleta_backendtest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, Driver+"_ReadOnly")
+	db, err := backend.Create(Driver, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := setKeys(db, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rdb, err := backend.CreateReadOnly(Driver, path)
+	if err == backend.ErrReadOnlyNotSupported {
+		t.Skip(Driver, "does not support the read-only open")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer rdb.Close()
+	rf, is := rdb.(backend.Refresher)
+	if !is {
+		t.Fatal("read-only backend should implement Refresher")
+	}
+
+	check := func(expected string) {
+		if err := rf.Refresh(); err != nil {
+			t.Fatal(err)
+		}
+		if result, err := listKeys(rdb, &backend.IterateOption{}); err != nil {
+			t.Fatal(err)
+		} else if result != expected {
+			t.Fatal("invalid keys", result, expected)
+		}
+	}
+	check("a,b")
+	if err := rdb.Update(func(txn backend.StoreWriter) error {
+		return txn.Set([]byte("x"), []byte("vx"))
+	}); err == nil {
+		t.Fatal("Update of the read-only backend should return an error")
+	}
+
+	if err := setKeys(db, []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		return txn.Delete([]byte("a"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	check("b,c")
+
+	// the read-only backend should follow the writer after the shrink
+	db.Shrink()
+	if err := setKeys(db, []string{"d"}); err != nil {
+		t.Fatal(err)
+	}
+	check("b,c,d")
+	check("b,c,d")
+}
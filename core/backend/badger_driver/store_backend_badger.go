@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger"
@@ -16,7 +17,9 @@ func init() {
 }
 
 type StoreBackendBadger struct {
-	db *badger.DB
+	sync.RWMutex
+	db       *badger.DB
+	isClosed bool
 }
 
 func NewStoreBackendBadger(path string) (backend.StoreBackend, error) {
@@ -56,6 +59,14 @@ func (st *StoreBackendBadger) Shrink() {
 }
 
 func (st *StoreBackendBadger) Close() {
+	st.Lock()
+	defer st.Unlock()
+
+	if st.isClosed {
+		return
+	}
+	st.isClosed = true
+
 	start := time.Now()
 	MaxCount := 10
 	Count := 0
@@ -72,6 +83,13 @@ again:
 }
 
 func (st *StoreBackendBadger) View(fn func(txn backend.StoreReader) error) error {
+	st.RLock()
+	defer st.RUnlock()
+
+	// badger does not return an error after the close
+	if st.isClosed {
+		return backend.ErrClosedBackend
+	}
 	if err := st.db.View(func(txn *badger.Txn) error {
 		r := &storeBackendBadgerTx{
 			txn: txn,
@@ -84,6 +102,12 @@ func (st *StoreBackendBadger) View(fn func(txn backend.StoreReader) error) error
 }
 
 func (st *StoreBackendBadger) Update(fn func(txn backend.StoreWriter) error) error {
+	st.RLock()
+	defer st.RUnlock()
+
+	if st.isClosed {
+		return backend.ErrClosedBackend
+	}
 	if err := st.db.Update(func(txn *badger.Txn) error {
 		r := &storeBackendBadgerTx{
 			txn: txn,
@@ -151,6 +175,43 @@ func (r *storeBackendBadgerTx) Iterate(prefix []byte, fn func(key []byte, value
 	return nil
 }
 
+func (r *storeBackendBadgerTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.Reverse = opt.Reverse
+	it := r.txn.NewIterator(itOpt)
+	defer it.Close()
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			it.Seek(upper)
+		} else {
+			it.Rewind()
+		}
+	} else {
+		it.Seek(opt.Lower())
+	}
+	for ; it.Valid(); it.Next() {
+		item := it.Item()
+		inRange, next := opt.Check(item.Key())
+		if inRange && !item.IsDeletedOrExpired() {
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err := fn(item.KeyCopy(nil), value); err != nil {
+				if err == backend.ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+		if !next {
+			break
+		}
+	}
+	return nil
+}
+
 func (r *storeBackendBadgerTx) Set(key []byte, value []byte) error {
 	if err := r.txn.Set(key, value); err != nil {
 		return err
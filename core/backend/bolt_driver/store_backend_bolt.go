@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -20,7 +21,7 @@ type StoreBackendBolt struct {
 }
 
 func NewStoreBackendBolt(path string) (backend.StoreBackend, error) {
-	os.MkdirAll(path, os.ModePerm)
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
 
 	start := time.Now()
 	db, err := bolt.Open(path, 0600, nil)
@@ -80,10 +81,10 @@ type StoreBackendBoltTx struct {
 func (r *StoreBackendBoltTx) Get(key []byte) ([]byte, error) {
 	bucket := r.txn.Bucket([]byte{0})
 	value := bucket.Get(key)
-	if len(value) == 0 {
+	if value == nil {
 		return nil, backend.ErrNotExistKey
 	}
-	return value, nil
+	return append([]byte{}, value...), nil
 }
 
 func (r *StoreBackendBoltTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
@@ -105,6 +106,45 @@ func (r *StoreBackendBoltTx) Iterate(prefix []byte, fn func(key []byte, value []
 	return nil
 }
 
+func (r *StoreBackendBoltTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	c := r.txn.Bucket([]byte{0}).Cursor()
+	var key, value []byte
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			if key, value = c.Seek(upper); key == nil {
+				key, value = c.Last()
+			} else if bytes.Compare(key, upper) > 0 {
+				key, value = c.Prev()
+			}
+		} else {
+			key, value = c.Last()
+		}
+	} else {
+		key, value = c.Seek(opt.Lower())
+	}
+	for key != nil {
+		inRange, next := opt.Check(key)
+		if inRange {
+			if err := fn(key, value); err != nil {
+				if err == backend.ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+		if !next {
+			break
+		}
+		if opt.Reverse {
+			key, value = c.Prev()
+		} else {
+			key, value = c.Next()
+		}
+	}
+	return nil
+}
+
 func (r *StoreBackendBoltTx) Set(key []byte, value []byte) error {
 	bucket := r.txn.Bucket([]byte{0})
 	if err := bucket.Put(key, value); err != nil {
@@ -58,6 +58,10 @@ var (
 
 	// ErrTxIterating is returned when Set or Delete are called while iterating.
 	ErrTxIterating = errors.New("tx is iterating")
+
+	// ErrReadOnly is returned when a writable transaction is started on the
+	// database opened with OpenReadOnly.
+	ErrReadOnly = errors.New("read-only database")
 )
 
 // DB represents a collection of key-value pairs that persist on disk.
@@ -76,6 +80,10 @@ type DB struct {
 	persist   bool              // do we write to disk
 	shrinking bool              // when an aof shrink is in-process.
 	lastaofsz int               // the size of the last shrink aof size
+	readOnly  bool              // the file is written by another process
+	rofile    *os.File          // the followed file of the read-only database
+	ropath    string            // the path of the followed file
+	ropos     int64             // the size of loaded transactions of the followed file
 }
 
 // SyncPolicy represents how often data is synced to disk.
@@ -172,6 +180,78 @@ func Open(path string) (*DB, error) {
 	return db, nil
 }
 
+// OpenReadOnly opens a database file which is written by another process.
+// All ended transactions of the file are loaded in memory and the database
+// is never written, so transactions appended after the open are loaded by Follow.
+func OpenReadOnly(path string) (*DB, error) {
+	db := &DB{}
+	db.keys = btree.New(btreeDegrees, nil)
+	db.exps = btree.New(btreeDegrees, &exctx{db})
+	db.idxs = make(map[string]*index)
+	db.config = Config{
+		SyncPolicy:         Never,
+		AutoShrinkDisabled: true,
+	}
+	db.readOnly = true
+	db.ropath = path
+	if err := db.Follow(); err != nil {
+		return nil, err
+	}
+	go db.backgroundManager()
+	return db, nil
+}
+
+// Follow loads transactions which are appended to the file after the last load.
+// The database is reloaded from the start when the file is replaced by the
+// shrink of the writer. A transaction which is being written is loaded by
+// the next call after its txend is written.
+func (db *DB) Follow() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.closed {
+		return ErrDatabaseClosed
+	}
+	if !db.readOnly {
+		return ErrInvalidOperation
+	}
+	fi, err := os.Stat(db.ropath)
+	if err != nil {
+		return err
+	}
+	if db.rofile != nil {
+		cfi, err := db.rofile.Stat()
+		if err != nil {
+			return err
+		}
+		if !os.SameFile(fi, cfi) || fi.Size() < db.ropos {
+			_ = db.rofile.Close()
+			db.rofile = nil
+		}
+	}
+	if db.rofile == nil {
+		file, err := os.Open(db.ropath)
+		if err != nil {
+			return err
+		}
+		db.rofile = file
+		db.ropos = 0
+		db.keys = btree.New(btreeDegrees, nil)
+		db.exps = btree.New(btreeDegrees, &exctx{db})
+		for _, idx := range db.idxs {
+			idx.rebuild()
+		}
+	}
+	if _, err := db.rofile.Seek(db.ropos, 0); err != nil {
+		return err
+	}
+	n, err := db.readTxs(db.rofile, fi.ModTime())
+	db.ropos += n
+	if err != nil && err != errIncompleteTx && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
 // Close releases all database resources.
 // All transactions must be closed before closing the database.
 func (db *DB) Close() error {
@@ -187,6 +267,10 @@ func (db *DB) Close() error {
 			return err
 		}
 	}
+	if db.rofile != nil {
+		_ = db.rofile.Close()
+		db.rofile = nil
+	}
 	// Let's release all references to nil. This will help both with debugging
 	// late usage panics and it provides a hint to the garbage collector
 	db.keys, db.exps, db.idxs, db.file = nil, nil, nil, nil
@@ -836,16 +920,30 @@ func (db *DB) Backup(dst string) error {
 
 var errValidEOF = errors.New("valid eof")
 
+// errIncompleteTx is returned when the last transaction is not ended by txend
+var errIncompleteTx = errors.New("incomplete transaction")
+
 // readLoad reads from the reader and loads commands into the database.
 // modTime is the modified time of the reader, should be no greater than
 // the current time.Now().
 func (db *DB) readLoad(file *os.File, modTime time.Time) error {
+	lastTxPos, err := db.readTxs(file, modTime)
+	if err == errIncompleteTx {
+		// ignore crashed commands before txend
+		return file.Truncate(lastTxPos)
+	}
+	return err
+}
+
+// readTxs reads from the reader and loads ended transactions into the database.
+// It returns the size of ended transactions from the start of the reader.
+func (db *DB) readTxs(rd io.Reader, modTime time.Time) (int64, error) {
 	data := make([]byte, 4096)
 	commiteds := make([][]string, 0)
 	parts := make([]string, 0, 8)
 	var fileOffset int64
 	var lastTxPos int64
-	r := bufio.NewReader(file)
+	r := bufio.NewReader(rd)
 	for {
 		// read a single command.
 		// first we should read the number of parts that the of the command
@@ -853,33 +951,30 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 		fileOffset += int64(len(line))
 		if err != nil {
 			if len(line) > 0 {
-				if err := file.Truncate(lastTxPos); err != nil {
-					return err
-				}
-				return nil // ignore crashed commands before txend
+				return lastTxPos, errIncompleteTx
 			}
 			if err == io.EOF {
 				break
 			}
-			return err
+			return lastTxPos, err
 		}
 		if line[0] != '*' {
-			return ErrInvalid
+			return lastTxPos, ErrInvalid
 		}
 		// convert the string number to and int
 		var n int
 		if len(line) == 4 && line[len(line)-2] == '\r' {
 			if line[1] < '0' || line[1] > '9' {
-				return ErrInvalid
+				return lastTxPos, ErrInvalid
 			}
 			n = int(line[1] - '0')
 		} else {
 			if len(line) < 5 || line[len(line)-2] != '\r' {
-				return ErrInvalid
+				return lastTxPos, ErrInvalid
 			}
 			for i := 1; i < len(line)-2; i++ {
 				if line[i] < '0' || line[i] > '9' {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 				n = n*10 + int(line[i]-'0')
 			}
@@ -891,25 +986,25 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 			line, err := r.ReadBytes('\n')
 			fileOffset += int64(len(line))
 			if err != nil {
-				return err
+				return lastTxPos, err
 			}
 			if line[0] != '$' {
-				return ErrInvalid
+				return lastTxPos, ErrInvalid
 			}
 			// convert the string number to and int
 			var n int
 			if len(line) == 4 && line[len(line)-2] == '\r' {
 				if line[1] < '0' || line[1] > '9' {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 				n = int(line[1] - '0')
 			} else {
 				if len(line) < 5 || line[len(line)-2] != '\r' {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 				for i := 1; i < len(line)-2; i++ {
 					if line[i] < '0' || line[i] > '9' {
-						return ErrInvalid
+						return lastTxPos, ErrInvalid
 					}
 					n = n*10 + int(line[i]-'0')
 				}
@@ -923,17 +1018,11 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 				data = make([]byte, dataln)
 			}
 			if _, err = io.ReadFull(r, data[:n+2]); err != nil {
-				if err := file.Truncate(lastTxPos); err != nil {
-					return err
-				}
-				return nil
+				return lastTxPos, errIncompleteTx
 			}
 			fileOffset += int64(n) + 2
 			if data[n] != '\r' || data[n+1] != '\n' {
-				if err := file.Truncate(lastTxPos); err != nil {
-					return err
-				}
-				return nil
+				return lastTxPos, errIncompleteTx
 			}
 			// copy string
 			parts = append(parts, string(data[:n]))
@@ -951,15 +1040,15 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 					(v[0][2] == 't' || v[0][2] == 'T') {
 					// SET
 					if len(v) < 3 || len(v) == 4 || len(v) > 5 {
-						return ErrInvalid
+						return lastTxPos, ErrInvalid
 					}
 					if len(v) == 5 {
 						if strings.ToLower(v[3]) != "ex" {
-							return ErrInvalid
+							return lastTxPos, ErrInvalid
 						}
 						ex, err := strconv.ParseInt(v[4], 10, 64)
 						if err != nil {
-							return err
+							return lastTxPos, err
 						}
 						now := time.Now()
 						dur := (time.Duration(ex) * time.Second) - now.Sub(modTime)
@@ -981,7 +1070,7 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 					(v[0][2] == 'l' || v[0][2] == 'L') {
 					// DEL
 					if len(v) != 2 {
-						return ErrInvalid
+						return lastTxPos, ErrInvalid
 					}
 					db.deleteFromDatabase(&dbItem{key: v[1]})
 				} else if (v[0][0] == 'f' || v[0][1] == 'F') &&
@@ -990,7 +1079,7 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 					db.exps = btree.New(btreeDegrees, &exctx{db})
 					db.idxs = make(map[string]*index)
 				} else {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 			}
 			commiteds = commiteds[:0]
@@ -1005,7 +1094,7 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 				(parts[0][2] == 't' || parts[0][2] == 'T') {
 				// SET
 				if len(parts) < 3 || len(parts) == 4 || len(parts) > 5 {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 				commiteds = append(commiteds, vs)
 			} else if (parts[0][0] == 'd' || parts[0][1] == 'D') &&
@@ -1013,21 +1102,18 @@ func (db *DB) readLoad(file *os.File, modTime time.Time) error {
 				(parts[0][2] == 'l' || parts[0][2] == 'L') {
 				// DEL
 				if len(parts) != 2 {
-					return ErrInvalid
+					return lastTxPos, ErrInvalid
 				}
 				commiteds = append(commiteds, vs)
 			} else if (parts[0][0] == 'f' || parts[0][1] == 'F') &&
 				strings.ToLower(parts[0]) == "flushdb" {
 				commiteds = append(commiteds, vs)
 			} else {
-				if err := file.Truncate(lastTxPos); err != nil {
-					return err
-				}
-				return nil
+				return lastTxPos, errIncompleteTx
 			}
 		}
 	}
-	return nil
+	return lastTxPos, nil
 }
 
 // load reads entries from the append only database file and fills the database.
@@ -1188,6 +1274,10 @@ func (db *DB) Begin(writable bool) (*Tx, error) {
 		tx.unlock()
 		return nil, ErrDatabaseClosed
 	}
+	if writable && db.readOnly {
+		tx.unlock()
+		return nil, ErrReadOnly
+	}
 	if writable {
 		// writable transactions have a writeContext object that
 		// contains information about changes to the database.
@@ -1,7 +1,6 @@
 package buntdb_driver
 
 import (
-	"bytes"
 	"log"
 	"os"
 	"path/filepath"
@@ -14,11 +13,13 @@ import (
 
 func init() {
 	backend.RegisterDriver("buntdb", NewStoreBackendBuntDB)
+	backend.RegisterReadOnlyDriver("buntdb", NewStoreBackendBuntDBReadOnly)
 }
 
 type StoreBackendBuntDB struct {
 	sync.Mutex
-	db *buntdb.DB
+	db       *buntdb.DB
+	readOnly bool
 }
 
 func NewStoreBackendBuntDB(path string) (backend.StoreBackend, error) {
@@ -36,7 +37,40 @@ func NewStoreBackendBuntDB(path string) (backend.StoreBackend, error) {
 	return back, nil
 }
 
+// NewStoreBackendBuntDBReadOnly opens the file of the writer process without writing
+// updates of the writer are loaded by Refresh
+func NewStoreBackendBuntDBReadOnly(path string) (backend.StoreBackend, error) {
+	start := time.Now()
+	db, err := buntdb.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("BuntDB is opened as read-only in", time.Now().Sub(start))
+	back := &StoreBackendBuntDB{
+		db:       db,
+		readOnly: true,
+	}
+	return back, nil
+}
+
+// Refresh loads transactions which are committed by the writer after the last refresh
+func (st *StoreBackendBuntDB) Refresh() error {
+	if !st.readOnly {
+		return nil
+	}
+	if err := st.db.Follow(); err != nil {
+		if err == buntdb.ErrDatabaseClosed {
+			return backend.ErrClosedBackend
+		}
+		return err
+	}
+	return nil
+}
+
 func (st *StoreBackendBuntDB) Shrink() {
+	if st.readOnly {
+		return
+	}
 	st.Lock()
 	defer st.Unlock()
 
@@ -71,7 +105,9 @@ func (st *StoreBackendBuntDB) Close() {
 	}()
 
 	start := time.Now()
-	st.db.Shrink()
+	if !st.readOnly {
+		st.db.Shrink()
+	}
 	st.db.Close()
 	log.Println("BuntDB is closed in", time.Now().Sub(start))
 }
@@ -89,6 +125,9 @@ func (st *StoreBackendBuntDB) View(fn func(txn backend.StoreReader) error) error
 }
 
 func (st *StoreBackendBuntDB) Update(fn func(txn backend.StoreWriter) error) error {
+	if st.readOnly {
+		return backend.ErrReadOnlyBackend
+	}
 	if err := st.db.Update(func(txn *buntdb.Tx) error {
 		r := &storeBackendBuntDBTx{
 			txn: txn,
@@ -118,25 +157,19 @@ func (r *storeBackendBuntDBTx) Get(key []byte) ([]byte, error) {
 
 func (r *storeBackendBuntDBTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
 	if len(prefix) > 0 {
-		end := make([]byte, len(prefix))
-		copy(end, prefix)
-		for i := len(end) - 1; i >= 0; i-- {
-			end[i]++
-			if end[i] != 0 {
-				break
-			}
-		}
-		if bytes.Compare(prefix, end) > 0 {
-			return nil
-		}
 		var inErr error
-		r.txn.AscendRange("", string(prefix), string(end), func(key string, value string) bool {
+		iter := func(key string, value string) bool {
 			if err := fn([]byte(key), []byte(value)); err != nil {
 				inErr = err
 				return false
 			}
 			return true
-		})
+		}
+		if end := backend.PrefixEnd(prefix); end != nil {
+			r.txn.AscendRange("", string(prefix), string(end), iter)
+		} else {
+			r.txn.AscendGreaterOrEqual("", string(prefix), iter)
+		}
 		if inErr != nil {
 			return inErr
 		}
@@ -156,6 +189,34 @@ func (r *storeBackendBuntDBTx) Iterate(prefix []byte, fn func(key []byte, value
 	return nil
 }
 
+func (r *storeBackendBuntDBTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	var inErr error
+	iter := func(key string, value string) bool {
+		inRange, next := opt.Check([]byte(key))
+		if inRange {
+			if err := fn([]byte(key), []byte(value)); err != nil {
+				inErr = err
+				return false
+			}
+		}
+		return next
+	}
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			r.txn.DescendLessOrEqual("", string(upper), iter)
+		} else {
+			r.txn.Descend("", iter)
+		}
+	} else {
+		r.txn.AscendGreaterOrEqual("", string(opt.Lower()), iter)
+	}
+	if inErr != nil && inErr != backend.ErrStopIteration {
+		return inErr
+	}
+	return nil
+}
+
 func (r *storeBackendBuntDBTx) Set(key []byte, value []byte) error {
 	if _, _, err := r.txn.Set(string(key), string(value), nil); err != nil {
 		return err
@@ -1,7 +1,6 @@
 package buntdb_old_driver
 
 import (
-	"bytes"
 	"log"
 	"os"
 	"path/filepath"
@@ -104,25 +103,19 @@ func (r *storeBackendBuntDBTx) Get(key []byte) ([]byte, error) {
 
 func (r *storeBackendBuntDBTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
 	if len(prefix) > 0 {
-		end := make([]byte, len(prefix))
-		copy(end, prefix)
-		for i := len(end) - 1; i >= 0; i-- {
-			end[i]++
-			if end[i] != 0 {
-				break
-			}
-		}
-		if bytes.Compare(prefix, end) > 0 {
-			return nil
-		}
 		var inErr error
-		r.txn.AscendRange("", string(prefix), string(end), func(key string, value string) bool {
+		iter := func(key string, value string) bool {
 			if err := fn([]byte(key), []byte(value)); err != nil {
 				inErr = err
 				return false
 			}
 			return true
-		})
+		}
+		if end := backend.PrefixEnd(prefix); end != nil {
+			r.txn.AscendRange("", string(prefix), string(end), iter)
+		} else {
+			r.txn.AscendGreaterOrEqual("", string(prefix), iter)
+		}
 		if inErr != nil {
 			return inErr
 		}
@@ -142,6 +135,34 @@ func (r *storeBackendBuntDBTx) Iterate(prefix []byte, fn func(key []byte, value
 	return nil
 }
 
+func (r *storeBackendBuntDBTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	var inErr error
+	iter := func(key string, value string) bool {
+		inRange, next := opt.Check([]byte(key))
+		if inRange {
+			if err := fn([]byte(key), []byte(value)); err != nil {
+				inErr = err
+				return false
+			}
+		}
+		return next
+	}
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			r.txn.DescendLessOrEqual("", string(upper), iter)
+		} else {
+			r.txn.Descend("", iter)
+		}
+	} else {
+		r.txn.AscendGreaterOrEqual("", string(opt.Lower()), iter)
+	}
+	if inErr != nil && inErr != backend.ErrStopIteration {
+		return inErr
+	}
+	return nil
+}
+
 func (r *storeBackendBuntDBTx) Set(key []byte, value []byte) error {
 	if _, _, err := r.txn.Set(string(key), string(value), nil); err != nil {
 		return err
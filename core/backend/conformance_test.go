@@ -0,0 +1,30 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/core/backend/backendtest"
+	_ "github.com/fletaio/fleta/core/backend/badger_driver"
+	_ "github.com/fletaio/fleta/core/backend/bolt_driver"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_old_driver"
+	_ "github.com/fletaio/fleta/core/backend/keydb_driver"
+	_ "github.com/fletaio/fleta/core/backend/leveldb_driver"
+	_ "github.com/fletaio/fleta/core/backend/memory_driver"
+)
+
+func TestConformance(t *testing.T) {
+	for _, Driver := range []string{"memory", "buntdb", "buntdb_old", "keydb", "leveldb", "bolt", "badger"} {
+		t.Run(Driver, func(t *testing.T) {
+			backendtest.Run(t, Driver, true)
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	for _, Driver := range []string{"memory", "buntdb", "buntdb_old", "keydb", "leveldb", "bolt", "badger"} {
+		t.Run(Driver, func(t *testing.T) {
+			backendtest.RunReadOnly(t, Driver)
+		})
+	}
+}
@@ -4,6 +4,16 @@ import "errors"
 
 // errors
 var (
-	ErrNotExistDriver = errors.New("not exist driver")
-	ErrNotExistKey    = errors.New("not exist key")
+	ErrNotExistDriver       = errors.New("not exist driver")
+	ErrNotExistKey          = errors.New("not exist key")
+	ErrInvalidLocation      = errors.New("invalid location")
+	ErrDriverMismatch       = errors.New("driver mismatch")
+	ErrSameDriver           = errors.New("same driver")
+	ErrNotEmptyBackend      = errors.New("not empty backend")
+	ErrMigrationMismatch    = errors.New("migration mismatch")
+	ErrStopIteration        = errors.New("stop iteration")
+	ErrClosedBackend        = errors.New("closed backend")
+	ErrReadOnlyBackend      = errors.New("read-only backend")
+	ErrReadOnlyNotSupported = errors.New("read-only is not supported")
+	ErrNotTypedValue        = errors.New("not typed value")
 )
@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"bytes"
+)
+
+// IterateOption defines the range, the direction and the limit of IterateRange
+// From is the first key of the iteration (inclusive) and it is the largest key in the reverse iteration
+// From lower than the Prefix is ignored in the forward iteration
+// zero Limit means no limit
+type IterateOption struct {
+	Prefix  []byte
+	From    []byte
+	Reverse bool
+	Limit   int
+}
+
+// PrefixEnd returns the smallest key which is greater than all keys that have the prefix
+// it returns nil when there is no such key
+func PrefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// Lower returns the first key of the forward iteration
+func (opt *IterateOption) Lower() []byte {
+	if opt.From != nil && bytes.Compare(opt.From, opt.Prefix) > 0 {
+		return opt.From
+	}
+	return opt.Prefix
+}
+
+// Upper returns the first key of the reverse iteration (nil means the last key)
+// the key can be out of the range and it should be checked by Check
+func (opt *IterateOption) Upper() []byte {
+	if opt.From != nil {
+		return opt.From
+	}
+	return PrefixEnd(opt.Prefix)
+}
+
+// Check returns whether the key is in the range and whether the iteration should be continued
+func (opt *IterateOption) Check(key []byte) (bool, bool) {
+	if !opt.Reverse {
+		return bytes.HasPrefix(key, opt.Prefix), bytes.HasPrefix(key, opt.Prefix)
+	}
+	if bytes.Compare(key, opt.Prefix) < 0 {
+		return false, false
+	}
+	if !bytes.HasPrefix(key, opt.Prefix) {
+		return false, true
+	}
+	if opt.From != nil && bytes.Compare(key, opt.From) > 0 {
+		return false, true
+	}
+	return true, true
+}
+
+// Limiter wraps the iteration callback to stop at the limit
+// the callback can return ErrStopIteration to stop the iteration without an error
+func (opt *IterateOption) Limiter(fn func(key []byte, value []byte) error) func(key []byte, value []byte) error {
+	count := 0
+	return func(key []byte, value []byte) error {
+		if opt.Limit > 0 && count >= opt.Limit {
+			return ErrStopIteration
+		}
+		count++
+		return fn(key, value)
+	}
+}
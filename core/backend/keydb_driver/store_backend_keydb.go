@@ -0,0 +1,303 @@
+package keydb_driver
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/keydb"
+)
+
+func init() {
+	backend.RegisterDriver("keydb", NewStoreBackendKeyDB)
+	encoding.Register(&typedValue{}, func(enc *encoding.Encoder, rv reflect.Value) error {
+		item := rv.Interface().(*typedValue)
+		if err := enc.EncodeBytes(item.data); err != nil {
+			return err
+		}
+		return nil
+	}, nil)
+}
+
+var gTypedValues bool
+
+// SetTypedValues makes the keydb opened after the call keep decoded values of keys of registered typed unmarshalers
+// it should be called before opening the backend
+func SetTypedValues(enabled bool) {
+	gTypedValues = enabled
+}
+
+// StoreBackendKeyDB keeps all keys in memory and appends updates to one file like buntdb
+// values are stored as decoded byte slices, so reads do not decode values
+// when typed values are enabled, values of registered typed unmarshalers also keep their decoded instance
+type StoreBackendKeyDB struct {
+	sync.Mutex
+	db    *keydb.DB
+	typed bool
+}
+
+// typedValue keeps the stored bytes and the decoded instance of them
+// the instance is decoded at the first read because types of values can be registered after the backend is opened
+type typedValue struct {
+	sync.Mutex
+	data  []byte
+	fn    backend.TypedUnmarshaler
+	value interface{}
+}
+
+// Value returns the decoded instance of the stored bytes
+func (tv *typedValue) Value() (interface{}, error) {
+	tv.Lock()
+	defer tv.Unlock()
+
+	if tv.value == nil {
+		v, err := tv.fn(tv.data)
+		if err != nil {
+			return nil, err
+		}
+		tv.value = v
+	}
+	return tv.value, nil
+}
+
+// unmarshalBytes decodes the value of the file to the byte slice directly
+// it is faster than the default unmarshaler which decodes to the interface
+func unmarshalBytes(key []byte, data []byte) (interface{}, error) {
+	var value []byte
+	if err := encoding.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// unmarshalTyped decodes the value of the file to the typed value when the key has the registered typed unmarshaler
+func unmarshalTyped(key []byte, data []byte) (interface{}, error) {
+	value, err := unmarshalBytes(key, data)
+	if err != nil {
+		return nil, err
+	}
+	if fn, has := backend.TypedUnmarshalerOf(key); has {
+		return &typedValue{data: value.([]byte), fn: fn}, nil
+	}
+	return value, nil
+}
+
+func NewStoreBackendKeyDB(path string) (backend.StoreBackend, error) {
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
+
+	typed := gTypedValues
+	unmarshaler := unmarshalBytes
+	if typed {
+		unmarshaler = unmarshalTyped
+	}
+	start := time.Now()
+	db, err := keydb.Open(path, unmarshaler)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("KeyDB is opened in", time.Now().Sub(start))
+	back := &StoreBackendKeyDB{
+		db:    db,
+		typed: typed,
+	}
+	return back, nil
+}
+
+// SetConfig updates the sync policy and the auto shrink options of the keydb
+func (st *StoreBackendKeyDB) SetConfig(config keydb.Config) error {
+	if err := st.db.SetConfig(config); err != nil {
+		if err == keydb.ErrDatabaseClosed {
+			return backend.ErrClosedBackend
+		}
+		return err
+	}
+	return nil
+}
+
+func (st *StoreBackendKeyDB) Shrink() {
+	st.Lock()
+	defer st.Unlock()
+
+	st.db.Shrink()
+}
+
+func (st *StoreBackendKeyDB) Close() {
+	st.Lock()
+	defer st.Unlock()
+
+	start := time.Now()
+	if err := st.db.Shrink(); err == keydb.ErrDatabaseClosed {
+		return
+	}
+	st.db.Close()
+	log.Println("KeyDB is closed in", time.Now().Sub(start))
+}
+
+func (st *StoreBackendKeyDB) View(fn func(txn backend.StoreReader) error) error {
+	if err := st.db.View(func(txn *keydb.Tx) error {
+		r := &storeBackendKeyDBTx{
+			txn:   txn,
+			typed: st.typed,
+		}
+		return fn(r)
+	}); err != nil {
+		if err == keydb.ErrDatabaseClosed {
+			return backend.ErrClosedBackend
+		}
+		return err
+	}
+	return nil
+}
+
+func (st *StoreBackendKeyDB) Update(fn func(txn backend.StoreWriter) error) error {
+	if err := st.db.Update(func(txn *keydb.Tx) error {
+		r := &storeBackendKeyDBTx{
+			txn:   txn,
+			typed: st.typed,
+		}
+		return fn(r)
+	}); err != nil {
+		if err == keydb.ErrDatabaseClosed {
+			return backend.ErrClosedBackend
+		}
+		return err
+	}
+	return nil
+}
+
+type storeBackendKeyDBTx struct {
+	txn   *keydb.Tx
+	typed bool
+}
+
+// copyValue returns the copy of the stored value because the value is shared with the memory of the keydb
+func copyValue(value interface{}) []byte {
+	var bs []byte
+	switch v := value.(type) {
+	case []byte:
+		bs = v
+	case *typedValue:
+		bs = v.data
+	}
+	v := make([]byte, len(bs))
+	copy(v, bs)
+	return v
+}
+
+func (r *storeBackendKeyDBTx) Get(key []byte) ([]byte, error) {
+	value, err := r.txn.Get(key)
+	if err != nil {
+		if err == keydb.ErrNotFound {
+			return nil, backend.ErrNotExistKey
+		} else {
+			return nil, err
+		}
+	}
+	return copyValue(value), nil
+}
+
+// GetTyped returns the decoded instance of the value and the size of the stored bytes
+func (r *storeBackendKeyDBTx) GetTyped(key []byte) (interface{}, int, error) {
+	value, err := r.txn.Get(key)
+	if err != nil {
+		if err == keydb.ErrNotFound {
+			return nil, 0, backend.ErrNotExistKey
+		} else {
+			return nil, 0, err
+		}
+	}
+	tv, is := value.(*typedValue)
+	if !is {
+		return nil, 0, backend.ErrNotTypedValue
+	}
+	v, err := tv.Value()
+	if err != nil {
+		return nil, 0, backend.ErrNotTypedValue
+	}
+	return v, len(tv.data), nil
+}
+
+func (r *storeBackendKeyDBTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	var inErr error
+	iter := func(key []byte, value interface{}) bool {
+		if err := fn(key, copyValue(value)); err != nil {
+			inErr = err
+			return false
+		}
+		return true
+	}
+	if len(prefix) == 0 {
+		r.txn.Ascend(iter)
+	} else if end := backend.PrefixEnd(prefix); end != nil {
+		r.txn.AscendRange(prefix, end, iter)
+	} else {
+		r.txn.AscendGreaterOrEqual(prefix, iter)
+	}
+	if inErr != nil {
+		return inErr
+	}
+	return nil
+}
+
+func (r *storeBackendKeyDBTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	var inErr error
+	iter := func(key []byte, value interface{}) bool {
+		inRange, next := opt.Check(key)
+		if inRange {
+			if err := fn(key, copyValue(value)); err != nil {
+				inErr = err
+				return false
+			}
+		}
+		return next
+	}
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			r.txn.DescendLessOrEqual(upper, iter)
+		} else {
+			r.txn.Descend(iter)
+		}
+	} else {
+		r.txn.AscendGreaterOrEqual(opt.Lower(), iter)
+	}
+	if inErr != nil && inErr != backend.ErrStopIteration {
+		return inErr
+	}
+	return nil
+}
+
+func (r *storeBackendKeyDBTx) Set(key []byte, value []byte) error {
+	k := make([]byte, len(key))
+	copy(k, key)
+	v := make([]byte, len(value))
+	copy(v, value)
+	if r.typed {
+		if fn, has := backend.TypedUnmarshalerOf(k); has {
+			if err := r.txn.Set(k, &typedValue{data: v, fn: fn}); err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+	if err := r.txn.Set(k, v); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *storeBackendKeyDBTx) Delete(key []byte) error {
+	if err := r.txn.Delete(key); err != nil {
+		if err == keydb.ErrNotFound {
+			return nil
+		} else {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package keydb_driver
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+)
+
+// chainWorkload generates updates which are similar to blocks of the chain
+// accounts are updated and deleted by the address and process data are appended by the height
+type chainWorkload struct {
+	rd       *rand.Rand
+	accounts [][]byte
+	height   int
+}
+
+func newChainWorkload(seed int64, Count int) *chainWorkload {
+	w := &chainWorkload{
+		rd:       rand.New(rand.NewSource(seed)),
+		accounts: make([][]byte, 0, Count),
+	}
+	for i := 0; i < Count; i++ {
+		key := make([]byte, 21)
+		key[0] = 'a'
+		w.rd.Read(key[1:])
+		w.accounts = append(w.accounts, key)
+	}
+	return w
+}
+
+func (w *chainWorkload) accountKey(i int) []byte {
+	return w.accounts[i]
+}
+
+func (w *chainWorkload) value(min int, max int) []byte {
+	bs := make([]byte, min+w.rd.Intn(max-min+1))
+	w.rd.Read(bs)
+	return bs
+}
+
+// block applies the updates of the next block
+func (w *chainWorkload) block(txn backend.StoreWriter, Updates int) error {
+	w.height++
+	for i := 0; i < Updates; i++ {
+		if err := txn.Set(w.accountKey(w.rd.Intn(len(w.accounts))), w.value(80, 160)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < Updates/20; i++ {
+		if err := txn.Delete(w.accountKey(w.rd.Intn(len(w.accounts)))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < 5; i++ {
+		key := []byte{'d', byte(w.height >> 16), byte(w.height >> 8), byte(w.height), byte(i)}
+		if err := txn.Set(key, w.value(0, 32)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openDrivers(t testing.TB, dir string) map[string]backend.StoreBackend {
+	dbs := map[string]backend.StoreBackend{}
+	for _, Driver := range []string{"keydb", "buntdb"} {
+		db, err := backend.Create(Driver, filepath.Join(dir, Driver))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dbs[Driver] = db
+	}
+	return dbs
+}
+
+func compareDrivers(t *testing.T, dbs map[string]backend.StoreBackend) {
+	expected, err := backend.DigestOf(dbs["buntdb"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := backend.DigestOf(dbs["keydb"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *result != *expected {
+		t.Fatal("keydb is not matched with buntdb", result, expected)
+	}
+}
+
+func TestChainWorkload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_keydb_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbs := openDrivers(t, dir)
+	workloads := map[string]*chainWorkload{}
+	for Driver := range dbs {
+		workloads[Driver] = newChainWorkload(1, 2000)
+	}
+	for h := 1; h <= 100; h++ {
+		for Driver, db := range dbs {
+			w := workloads[Driver]
+			if err := db.Update(func(txn backend.StoreWriter) error {
+				return w.block(txn, 200)
+			}); err != nil {
+				t.Fatal(Driver, h, err)
+			}
+		}
+		if h%25 == 0 {
+			compareDrivers(t, dbs)
+		}
+		if h == 50 {
+			dbs["keydb"].Shrink()
+		}
+	}
+	for _, db := range dbs {
+		db.Close()
+	}
+
+	// the keydb should load the same data from the file
+	dbs = openDrivers(t, dir)
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+	compareDrivers(t, dbs)
+}
+
+// typedString is the typed value of the test which is decoded from the stored bytes
+type typedString struct {
+	value string
+}
+
+func TestTypedValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_keydb_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	backend.RegisterTypedUnmarshaler([]byte{'t'}, func(value []byte) (interface{}, error) {
+		return &typedString{value: string(value)}, nil
+	})
+	SetTypedValues(true)
+	defer SetTypedValues(false)
+
+	path := filepath.Join(dir, "keydb")
+	check := func(db backend.StoreBackend) {
+		if err := db.View(func(txn backend.StoreReader) error {
+			tr := txn.(backend.TypedReader)
+			v, size, err := tr.GetTyped([]byte("t1"))
+			if err != nil {
+				return err
+			}
+			if v.(*typedString).value != "value1" || size != 6 {
+				t.Fatal("invalid typed value", v, size)
+			}
+			if value, err := txn.Get([]byte("t1")); err != nil {
+				return err
+			} else if string(value) != "value1" {
+				t.Fatal("invalid value", string(value))
+			}
+			if _, _, err := tr.GetTyped([]byte("d1")); err != backend.ErrNotTypedValue {
+				t.Fatalf("expected %v but %v", backend.ErrNotTypedValue, err)
+			}
+			if _, _, err := tr.GetTyped([]byte("t2")); err != backend.ErrNotExistKey {
+				t.Fatalf("expected %v but %v", backend.ErrNotExistKey, err)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, err := backend.Create("keydb", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		if err := txn.Set([]byte("t1"), []byte("value1")); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte("d1"), []byte("data1")); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	check(db)
+	db.Close()
+
+	// typed values should be stored as bytes, so they are loaded again from the file
+	db, err = backend.Create("keydb", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	check(db)
+}
+
+func benchmarkBlockCommit(b *testing.B, Driver string) {
+	dir, err := ioutil.TempDir("", "fleta_keydb_")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := backend.Create(Driver, filepath.Join(dir, Driver))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	w := newChainWorkload(1, 100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := db.Update(func(txn backend.StoreWriter) error {
+			return w.block(txn, 500)
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlockCommitKeyDB(b *testing.B)  { benchmarkBlockCommit(b, "keydb") }
+func BenchmarkBlockCommitBuntDB(b *testing.B) { benchmarkBlockCommit(b, "buntdb") }
+
+func benchmarkAccountGet(b *testing.B, Driver string) {
+	dir, err := ioutil.TempDir("", "fleta_keydb_")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := backend.Create(Driver, filepath.Join(dir, Driver))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	w := newChainWorkload(1, 10000)
+	for i := 0; i < 20; i++ {
+		if err := db.Update(func(txn backend.StoreWriter) error {
+			return w.block(txn, 1000)
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := w.accountKey(i % len(w.accounts))
+		if err := db.View(func(txn backend.StoreReader) error {
+			if _, err := txn.Get(key); err != nil && err != backend.ErrNotExistKey {
+				return err
+			}
+			return nil
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAccountGetKeyDB(b *testing.B)  { benchmarkAccountGet(b, "keydb") }
+func BenchmarkAccountGetBuntDB(b *testing.B) { benchmarkAccountGet(b, "buntdb") }
+
+func benchmarkLoad(b *testing.B, Driver string) {
+	dir, err := ioutil.TempDir("", "fleta_keydb_")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, Driver)
+	db, err := backend.Create(Driver, path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	w := newChainWorkload(1, 10000)
+	for i := 0; i < 50; i++ {
+		if err := db.Update(func(txn backend.StoreWriter) error {
+			return w.block(txn, 1000)
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	db.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		db, err := backend.Create(Driver, path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StopTimer()
+		db.Close()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkLoadKeyDB(b *testing.B)  { benchmarkLoad(b, "keydb") }
+func BenchmarkLoadBuntDB(b *testing.B) { benchmarkLoad(b, "buntdb") }
@@ -98,18 +98,7 @@ func (r *storeBackendLevelDBTx) Get(key []byte) ([]byte, error) {
 func (r *storeBackendLevelDBTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
 	var rg *util.Range
 	if len(prefix) > 0 {
-		end := make([]byte, len(prefix))
-		copy(end, prefix)
-		for i := len(end) - 1; i >= 0; i-- {
-			end[i]++
-			if end[i] != 0 {
-				break
-			}
-		}
-		if bytes.Compare(prefix, end) > 0 {
-			return nil
-		}
-		rg = &util.Range{Start: prefix, Limit: end}
+		rg = &util.Range{Start: prefix, Limit: backend.PrefixEnd(prefix)}
 	}
 	it := r.txn.NewIterator(rg, nil)
 	defer it.Release()
@@ -121,6 +110,46 @@ func (r *storeBackendLevelDBTx) Iterate(prefix []byte, fn func(key []byte, value
 	return nil
 }
 
+func (r *storeBackendLevelDBTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	it := r.txn.NewIterator(nil, nil)
+	defer it.Release()
+	var ok bool
+	if opt.Reverse {
+		if upper := opt.Upper(); upper != nil {
+			if ok = it.Seek(upper); !ok {
+				ok = it.Last()
+			} else if bytes.Compare(it.Key(), upper) > 0 {
+				ok = it.Prev()
+			}
+		} else {
+			ok = it.Last()
+		}
+	} else {
+		ok = it.Seek(opt.Lower())
+	}
+	for ok {
+		inRange, next := opt.Check(it.Key())
+		if inRange {
+			if err := fn(it.Key(), it.Value()); err != nil {
+				if err == backend.ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+		if !next {
+			break
+		}
+		if opt.Reverse {
+			ok = it.Prev()
+		} else {
+			ok = it.Next()
+		}
+	}
+	return it.Error()
+}
+
 func (r *storeBackendLevelDBTx) Set(key []byte, value []byte) error {
 	if err := r.txn.Put(key, value, nil); err != nil {
 		return err
@@ -0,0 +1,126 @@
+package backend
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DefaultDriver is the driver of the context DB which is created before the location file
+const DefaultDriver = "buntdb"
+
+const locationFileName = "backend.json"
+const legacyContextPath = "context"
+
+// Location is the driver and the path of the context DB in the store root
+type Location struct {
+	Driver string `json:"driver"`
+	Path   string `json:"path"`
+}
+
+// LoadLocation returns the location of the context DB in the store root
+// it returns the legacy location when the location file is not exist
+func LoadLocation(StoreRoot string) (*Location, bool, error) {
+	bs, err := ioutil.ReadFile(filepath.Join(StoreRoot, locationFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Location{
+				Driver: DefaultDriver,
+				Path:   legacyContextPath,
+			}, false, nil
+		}
+		return nil, false, err
+	}
+	var loc Location
+	if err := json.Unmarshal(bs, &loc); err != nil {
+		return nil, false, err
+	}
+	if len(loc.Driver) == 0 || len(loc.Path) == 0 {
+		return nil, false, ErrInvalidLocation
+	}
+	return &loc, true, nil
+}
+
+// SaveLocation replaces the location file atomically
+func SaveLocation(StoreRoot string, loc *Location) error {
+	bs, err := json.Marshal(loc)
+	if err != nil {
+		return err
+	}
+	os.MkdirAll(StoreRoot, os.ModePerm)
+	path := filepath.Join(StoreRoot, locationFileName)
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(bs); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	if dir, err := os.Open(StoreRoot); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// Open opens the context DB of the store root
+// Driver is the configured driver and it should be same with the driver of the location when it is given
+func Open(StoreRoot string, Driver string) (StoreBackend, *Location, error) {
+	loc, has, err := LoadLocation(StoreRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !has {
+		if _, err := os.Stat(filepath.Join(StoreRoot, loc.Path)); os.IsNotExist(err) && len(Driver) > 0 {
+			loc.Driver = Driver
+		}
+	}
+	if len(Driver) > 0 && loc.Driver != Driver {
+		return nil, nil, ErrDriverMismatch
+	}
+	db, err := Create(loc.Driver, filepath.Join(StoreRoot, loc.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !has {
+		if err := SaveLocation(StoreRoot, loc); err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+	}
+	return db, loc, nil
+}
+
+// OpenReadOnly opens the context DB of the store root without writing
+// the location is not created, so the store root should be initialized by the writer process
+func OpenReadOnly(StoreRoot string, Driver string) (StoreBackend, *Location, error) {
+	loc, _, err := LoadLocation(StoreRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(Driver) > 0 && loc.Driver != Driver {
+		return nil, nil, ErrDriverMismatch
+	}
+	path := filepath.Join(StoreRoot, loc.Path)
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil, err
+	}
+	db, err := CreateReadOnly(loc.Driver, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, loc, nil
+}
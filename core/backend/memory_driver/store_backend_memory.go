@@ -0,0 +1,259 @@
+package memory_driver
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/fletaio/fleta/core/backend"
+)
+
+func init() {
+	backend.RegisterDriver("memory", NewStoreBackendMemory)
+	backend.RegisterReadOnlyDriver("memory", NewStoreBackendMemoryReadOnly)
+}
+
+// stores of the process by the path to reopen the closed store in the same process
+var (
+	gStoreLock sync.Mutex
+	gStoreMap  = map[string]*memoryStore{}
+)
+
+type memoryStore struct {
+	sync.RWMutex
+	keys   []string
+	values map[string][]byte
+}
+
+// StoreBackendMemory keeps all keys in the memory of the process
+// it is for tests and the data is remained until the process is terminated
+type StoreBackendMemory struct {
+	sync.Mutex
+	store    *memoryStore
+	isClosed bool
+	readOnly bool
+}
+
+// NewStoreBackendMemory returns a StoreBackendMemory which shares the data with the previous one of the same path
+func NewStoreBackendMemory(path string) (backend.StoreBackend, error) {
+	gStoreLock.Lock()
+	defer gStoreLock.Unlock()
+
+	ms, has := gStoreMap[path]
+	if !has {
+		ms = &memoryStore{
+			keys:   []string{},
+			values: map[string][]byte{},
+		}
+		gStoreMap[path] = ms
+	}
+	back := &StoreBackendMemory{
+		store: ms,
+	}
+	return back, nil
+}
+
+// NewStoreBackendMemoryReadOnly returns a StoreBackendMemory which reads the data of the path without writing
+// updates of the writer are visible immediately because the data is shared in the process
+func NewStoreBackendMemoryReadOnly(path string) (backend.StoreBackend, error) {
+	back, err := NewStoreBackendMemory(path)
+	if err != nil {
+		return nil, err
+	}
+	back.(*StoreBackendMemory).readOnly = true
+	return back, nil
+}
+
+// Refresh does nothing because the data is shared with the writer
+func (st *StoreBackendMemory) Refresh() error {
+	if st.closed() {
+		return backend.ErrClosedBackend
+	}
+	return nil
+}
+
+// Remove removes the data of the path
+func Remove(path string) {
+	gStoreLock.Lock()
+	defer gStoreLock.Unlock()
+
+	delete(gStoreMap, path)
+}
+
+func (st *StoreBackendMemory) Shrink() {
+}
+
+func (st *StoreBackendMemory) Close() {
+	st.Lock()
+	defer st.Unlock()
+
+	st.isClosed = true
+}
+
+func (st *StoreBackendMemory) closed() bool {
+	st.Lock()
+	defer st.Unlock()
+
+	return st.isClosed
+}
+
+func (st *StoreBackendMemory) View(fn func(txn backend.StoreReader) error) error {
+	if st.closed() {
+		return backend.ErrClosedBackend
+	}
+	st.store.RLock()
+	defer st.store.RUnlock()
+
+	r := &storeBackendMemoryTx{
+		store: st.store,
+	}
+	return fn(r)
+}
+
+func (st *StoreBackendMemory) Update(fn func(txn backend.StoreWriter) error) error {
+	if st.closed() {
+		return backend.ErrClosedBackend
+	}
+	if st.readOnly {
+		return backend.ErrReadOnlyBackend
+	}
+	st.store.Lock()
+	defer st.store.Unlock()
+
+	r := &storeBackendMemoryTx{
+		store: st.store,
+		undo:  map[string][]byte{},
+	}
+	if err := fn(r); err != nil {
+		r.rollback()
+		return err
+	}
+	return nil
+}
+
+type storeBackendMemoryTx struct {
+	store *memoryStore
+	undo  map[string][]byte
+}
+
+func (r *storeBackendMemoryTx) Get(key []byte) ([]byte, error) {
+	value, has := r.store.values[string(key)]
+	if !has {
+		return nil, backend.ErrNotExistKey
+	}
+	return append([]byte{}, value...), nil
+}
+
+func (r *storeBackendMemoryTx) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	return r.IterateRange(&backend.IterateOption{Prefix: prefix}, fn)
+}
+
+func (r *storeBackendMemoryTx) IterateRange(opt *backend.IterateOption, fn func(key []byte, value []byte) error) error {
+	fn = opt.Limiter(fn)
+	// keys are copied to allow the modification in the iteration
+	var keys []string
+	if opt.Reverse {
+		end := len(r.store.keys)
+		if upper := opt.Upper(); upper != nil {
+			end = sort.SearchStrings(r.store.keys, string(upper))
+			if end < len(r.store.keys) && r.store.keys[end] == string(upper) {
+				end++
+			}
+		}
+		keys = make([]string, end)
+		copy(keys, r.store.keys[:end])
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	} else {
+		begin := sort.SearchStrings(r.store.keys, string(opt.Lower()))
+		end := len(r.store.keys)
+		if upper := backend.PrefixEnd(opt.Prefix); upper != nil && len(opt.Prefix) > 0 {
+			end = sort.SearchStrings(r.store.keys, string(upper))
+		}
+		if begin < end {
+			keys = make([]string, end-begin)
+			copy(keys, r.store.keys[begin:end])
+		}
+	}
+	for _, k := range keys {
+		key := []byte(k)
+		inRange, next := opt.Check(key)
+		if !next {
+			break
+		}
+		if !inRange {
+			continue
+		}
+		value, has := r.store.values[k]
+		if !has {
+			continue
+		}
+		if err := fn(key, append([]byte{}, value...)); err != nil {
+			if err == backend.ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *storeBackendMemoryTx) Set(key []byte, value []byte) error {
+	k := string(key)
+	r.backup(k)
+	if _, has := r.store.values[k]; !has {
+		r.insertKey(k)
+	}
+	r.store.values[k] = append([]byte{}, value...)
+	return nil
+}
+
+func (r *storeBackendMemoryTx) Delete(key []byte) error {
+	k := string(key)
+	if _, has := r.store.values[k]; !has {
+		return nil
+	}
+	r.backup(k)
+	r.removeKey(k)
+	delete(r.store.values, k)
+	return nil
+}
+
+// backup stores the previous value of the key at the first modification (nil means not exist)
+func (r *storeBackendMemoryTx) backup(k string) {
+	if _, has := r.undo[k]; has {
+		return
+	}
+	r.undo[k] = r.store.values[k]
+}
+
+func (r *storeBackendMemoryTx) rollback() {
+	for k, value := range r.undo {
+		_, has := r.store.values[k]
+		if value == nil {
+			if has {
+				r.removeKey(k)
+				delete(r.store.values, k)
+			}
+		} else {
+			if !has {
+				r.insertKey(k)
+			}
+			r.store.values[k] = value
+		}
+	}
+}
+
+func (r *storeBackendMemoryTx) insertKey(k string) {
+	idx := sort.SearchStrings(r.store.keys, k)
+	r.store.keys = append(r.store.keys, "")
+	copy(r.store.keys[idx+1:], r.store.keys[idx:])
+	r.store.keys[idx] = k
+}
+
+func (r *storeBackendMemoryTx) removeKey(k string) {
+	idx := sort.SearchStrings(r.store.keys, k)
+	if idx < len(r.store.keys) && r.store.keys[idx] == k {
+		r.store.keys = append(r.store.keys[:idx], r.store.keys[idx+1:]...)
+	}
+}
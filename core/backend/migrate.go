@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// DefaultBatchSize is the number of keys in a batch of the migration
+const DefaultBatchSize = 10000
+
+// Digest is the number of keys and the hash of all keys and values in the key order
+type Digest struct {
+	Count uint64
+	Hash  hash.Hash256
+}
+
+type digester struct {
+	count uint64
+	hash  hash.Hash256
+}
+
+func (d *digester) add(key []byte, value []byte) {
+	bs := make([]byte, 0, 32+8+len(key)+len(value))
+	bs = append(bs, d.hash[:]...)
+	bs = append(bs, binutil.LittleEndian.Uint32ToBytes(uint32(len(key)))...)
+	bs = append(bs, key...)
+	bs = append(bs, binutil.LittleEndian.Uint32ToBytes(uint32(len(value)))...)
+	bs = append(bs, value...)
+	d.hash = hash.Hash(bs)
+	d.count++
+}
+
+func (d *digester) digest() *Digest {
+	return &Digest{
+		Count: d.count,
+		Hash:  d.hash,
+	}
+}
+
+// DigestOf returns the digest of all keys and values of the backend
+func DigestOf(db StoreBackend) (*Digest, error) {
+	d := &digester{}
+	if err := db.View(func(txn StoreReader) error {
+		return txn.Iterate([]byte{}, func(key []byte, value []byte) error {
+			d.add(key, value)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return d.digest(), nil
+}
+
+// Copy streams all keys of the source to the empty destination in batches and returns the digest of the source
+func Copy(src StoreBackend, dst StoreBackend, BatchSize int) (*Digest, error) {
+	if BatchSize <= 0 {
+		BatchSize = DefaultBatchSize
+	}
+	if err := dst.View(func(txn StoreReader) error {
+		return txn.Iterate([]byte{}, func(key []byte, value []byte) error {
+			return ErrNotEmptyBackend
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	type item struct {
+		key   []byte
+		value []byte
+	}
+	batch := make([]*item, 0, BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := dst.Update(func(txn StoreWriter) error {
+			for _, v := range batch {
+				if err := txn.Set(v.key, v.value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	d := &digester{}
+	start := time.Now()
+	if err := src.View(func(txn StoreReader) error {
+		return txn.Iterate([]byte{}, func(key []byte, value []byte) error {
+			d.add(key, value)
+			batch = append(batch, &item{
+				key:   append([]byte{}, key...),
+				value: append([]byte{}, value...),
+			})
+			if len(batch) >= BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+				log.Println("Backend copied", d.count, "keys in", time.Now().Sub(start))
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return d.digest(), nil
+}
+
+// Migrate moves the context DB of the store root to the driver and switches the location after the verification
+// the previous context DB is remained and it should be removed manually
+func Migrate(StoreRoot string, Driver string, BatchSize int) (*Location, error) {
+	if _, has := gDriverMap[Driver]; !has {
+		return nil, ErrNotExistDriver
+	}
+	loc, _, err := LoadLocation(StoreRoot)
+	if err != nil {
+		return nil, err
+	}
+	if loc.Driver == Driver {
+		return nil, ErrSameDriver
+	}
+
+	next := &Location{
+		Driver: Driver,
+		Path:   "context_" + Driver,
+	}
+	if next.Path == loc.Path {
+		next.Path = next.Path + "_" + time.Now().Format("20060102150405")
+	}
+	NextPath := filepath.Join(StoreRoot, next.Path)
+	if err := os.RemoveAll(NextPath); err != nil {
+		return nil, err
+	}
+
+	src, err := Create(loc.Driver, filepath.Join(StoreRoot, loc.Path))
+	if err != nil {
+		return nil, err
+	}
+	dst, err := Create(next.Driver, NextPath)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	sd, err := Copy(src, dst, BatchSize)
+	src.Close()
+	if err != nil {
+		dst.Close()
+		return nil, err
+	}
+	dd, err := DigestOf(dst)
+	dst.Close()
+	if err != nil {
+		return nil, err
+	}
+	if sd.Count != dd.Count || sd.Hash != dd.Hash {
+		log.Println("Backend migration is not matched", sd.Count, sd.Hash.String(), dd.Count, dd.Hash.String())
+		return nil, ErrMigrationMismatch
+	}
+	log.Println("Backend is migrated", sd.Count, "keys", sd.Hash.String())
+
+	if err := SaveLocation(StoreRoot, next); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,71 @@
+package backend_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/bolt_driver"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	_ "github.com/fletaio/fleta/core/backend/leveldb_driver"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_backend_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, loc, err := backend.Open(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Driver != backend.DefaultDriver {
+		t.Fatal("invalid default driver", loc.Driver)
+	}
+	if err := db.Update(func(txn backend.StoreWriter) error {
+		for i := 0; i < 1000; i++ {
+			if err := txn.Set([]byte("key"+strconv.Itoa(i)), []byte("value"+strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := backend.DigestOf(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	for _, Driver := range []string{"leveldb", "bolt", "buntdb"} {
+		if _, err := backend.Migrate(dir, Driver, 64); err != nil {
+			t.Fatal(Driver, err)
+		}
+		if _, _, err := backend.Open(dir, backend.DefaultDriver+"_old"); err != backend.ErrDriverMismatch {
+			t.Fatal("driver mismatch is not detected", Driver, err)
+		}
+		db, loc, err := backend.Open(dir, Driver)
+		if err != nil {
+			t.Fatal(Driver, err)
+		}
+		if loc.Driver != Driver {
+			t.Fatal("location is not switched", loc.Driver, Driver)
+		}
+		d, err := backend.DigestOf(db)
+		db.Close()
+		if err != nil {
+			t.Fatal(Driver, err)
+		}
+		if d.Count != expected.Count || d.Hash != expected.Hash {
+			t.Fatal("migrated data is different", Driver, d.Count, expected.Count)
+		}
+	}
+	if _, err := backend.Migrate(dir, "buntdb", 64); err != backend.ErrSameDriver {
+		t.Fatal("migration to the same driver is not rejected", err)
+	}
+}
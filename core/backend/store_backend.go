@@ -1,5 +1,7 @@
 package backend
 
+import "bytes"
+
 type StoreBackend interface {
 	Shrink()
 	Close()
@@ -10,6 +12,7 @@ type StoreBackend interface {
 type StoreReader interface {
 	Get(key []byte) ([]byte, error)
 	Iterate(prefix []byte, fn func(key []byte, value []byte) error) error
+	IterateRange(opt *IterateOption, fn func(key []byte, value []byte) error) error
 }
 
 type StoreWriter interface {
@@ -18,9 +21,15 @@ type StoreWriter interface {
 	Delete(key []byte) error
 }
 
+// Refresher is implemented by the read-only backend which follows updates of the writer process
+type Refresher interface {
+	Refresh() error
+}
+
 type CreateBackend func(Paht string) (StoreBackend, error)
 
 var gDriverMap = map[string]CreateBackend{}
+var gReadOnlyDriverMap = map[string]CreateBackend{}
 
 func RegisterDriver(Name string, fn CreateBackend) {
 	gDriverMap[Name] = fn
@@ -33,3 +42,55 @@ func Create(Name string, Path string) (StoreBackend, error) {
 	}
 	return fn(Path)
 }
+
+// RegisterReadOnlyDriver registers the read-only opener of the driver
+// the read-only backend should be opened while the writer process is running on the same path
+func RegisterReadOnlyDriver(Name string, fn CreateBackend) {
+	gReadOnlyDriverMap[Name] = fn
+}
+
+// CreateReadOnly opens the backend without writing
+func CreateReadOnly(Name string, Path string) (StoreBackend, error) {
+	if _, has := gDriverMap[Name]; !has {
+		return nil, ErrNotExistDriver
+	}
+	fn, has := gReadOnlyDriverMap[Name]
+	if !has {
+		return nil, ErrReadOnlyNotSupported
+	}
+	return fn(Path)
+}
+
+// TypedReader is implemented by the reader which keeps decoded values of keys of registered typed unmarshalers
+// the returned value is shared with the backend, so it should not be modified
+type TypedReader interface {
+	GetTyped(key []byte) (interface{}, int, error)
+}
+
+// TypedUnmarshaler decodes the stored value to the typed value
+type TypedUnmarshaler func(value []byte) (interface{}, error)
+
+type typedUnmarshalerItem struct {
+	prefix []byte
+	fn     TypedUnmarshaler
+}
+
+var gTypedUnmarshalers = []*typedUnmarshalerItem{}
+
+// RegisterTypedUnmarshaler registers the unmarshaler of values of which keys have the prefix
+func RegisterTypedUnmarshaler(prefix []byte, fn TypedUnmarshaler) {
+	gTypedUnmarshalers = append(gTypedUnmarshalers, &typedUnmarshalerItem{
+		prefix: prefix,
+		fn:     fn,
+	})
+}
+
+// TypedUnmarshalerOf returns the registered typed unmarshaler of the key
+func TypedUnmarshalerOf(key []byte) (TypedUnmarshaler, bool) {
+	for _, item := range gTypedUnmarshalers {
+		if bytes.HasPrefix(key, item.prefix) {
+			return item.fn, true
+		}
+	}
+	return nil, false
+}
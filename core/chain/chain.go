@@ -25,6 +25,7 @@ type Chain struct {
 	serviceMap      map[string]types.Service
 	closeLock       sync.RWMutex
 	isClose         bool
+	batch           *batchMode
 }
 
 // NewChain returns a Chain
@@ -131,15 +132,7 @@ func (cn *Chain) Init(InitGenesisHash hash.Hash256, initHash hash.Hash256, initH
 
 	// OnLoadChain
 	ctx := types.NewContext(cn.store)
-	for i, p := range cn.processes {
-		if err := p.OnLoadChain(types.NewContextWrapper(IDMap[i], ctx)); err != nil {
-			return err
-		}
-	}
-	if err := cn.app.OnLoadChain(types.NewContextWrapper(255, ctx)); err != nil {
-		return err
-	}
-	if err := cn.consensus.OnLoadChain(types.NewContextWrapper(0, ctx)); err != nil {
+	if err := cn.loadChain(ctx); err != nil {
 		return err
 	}
 	for _, s := range cn.services {
@@ -154,6 +147,40 @@ func (cn *Chain) Init(InitGenesisHash hash.Hash256, initHash hash.Hash256, initH
 	return nil
 }
 
+// Reload loads the memory states of processes and the consensus again from the store
+// it is used by the read only node of which the store is written by the other process
+func (cn *Chain) Reload() error {
+	cn.closeLock.RLock()
+	defer cn.closeLock.RUnlock()
+	if cn.isClose {
+		return ErrChainClosed
+	}
+
+	cn.Lock()
+	defer cn.Unlock()
+
+	return cn.loadChain(types.NewContext(cn.store))
+}
+
+func (cn *Chain) loadChain(ctx *types.Context) error {
+	IDMap := map[int]uint8{}
+	for id, idx := range cn.processIndexMap {
+		IDMap[idx] = id
+	}
+	for i, p := range cn.processes {
+		if err := p.OnLoadChain(types.NewContextWrapper(IDMap[i], ctx)); err != nil {
+			return err
+		}
+	}
+	if err := cn.app.OnLoadChain(types.NewContextWrapper(255, ctx)); err != nil {
+		return err
+	}
+	if err := cn.consensus.OnLoadChain(types.NewContextWrapper(0, ctx)); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Provider returns a chain provider
 func (cn *Chain) Provider() types.Provider {
 	return cn.store
@@ -168,6 +195,11 @@ func (cn *Chain) Close() {
 	defer cn.Unlock()
 
 	if !cn.isClose {
+		if cn.batch != nil {
+			if err := cn.stopBatchMode(); err != nil {
+				log.Println("Chain failed to flush blocks", err)
+			}
+		}
 		cn.store.Close()
 		cn.isClose = true
 	}
@@ -280,7 +312,7 @@ func (cn *Chain) ConnectBlock(b *types.Block, SigMap map[hash.Hash256][]common.P
 		return err
 	}
 
-	ctx := types.NewContext(cn.store)
+	ctx := cn.newContext()
 	if err := cn.executeBlockOnContext(b, ctx, SigMap); err != nil {
 		return err
 	}
@@ -322,7 +354,11 @@ func (cn *Chain) connectBlockWithContext(b *types.Block, ctx *types.Context) err
 	}
 
 	top := ctx.Top()
-	if err := cn.store.StoreBlock(b, top); err != nil {
+	if cn.batch != nil {
+		if err := cn.pushBatchBlock(b, ctx); err != nil {
+			return err
+		}
+	} else if err := cn.store.StoreBlock(b, top); err != nil {
 		return err
 	}
 	for _, s := range cn.services {
@@ -0,0 +1,178 @@
+package chain
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/core/types"
+)
+
+// batchMode keeps contexts of pending blocks and flushes pending blocks in the background
+type batchMode struct {
+	sync.Mutex
+	count    int
+	interval time.Duration
+	contexts []*types.Context
+	heights  []uint32
+	flushC   chan struct{}
+	closeC   chan struct{}
+	wg       sync.WaitGroup
+	err      error
+}
+
+func (bm *batchMode) setError(err error) {
+	bm.Lock()
+	defer bm.Unlock()
+
+	if bm.err == nil {
+		bm.err = err
+	}
+}
+
+func (bm *batchMode) lastError() error {
+	bm.Lock()
+	defer bm.Unlock()
+
+	return bm.err
+}
+
+// SetBatchMode enables the batched persistence of blocks which is used for the high-throughput sync
+// blocks are executed on chained contexts and flushed when Count blocks are pending or Interval is passed
+// the state of the store is updated when blocks are flushed, so queries of the store can be behind the height
+// zero Count disables the batch mode after flushing pending blocks
+func (cn *Chain) SetBatchMode(Count int, Interval time.Duration) error {
+	cn.closeLock.RLock()
+	defer cn.closeLock.RUnlock()
+	if cn.isClose {
+		return ErrChainClosed
+	}
+
+	cn.Lock()
+	defer cn.Unlock()
+
+	if cn.batch != nil {
+		if err := cn.stopBatchMode(); err != nil {
+			return err
+		}
+	}
+	if Count <= 0 {
+		return nil
+	}
+	bm := &batchMode{
+		count:    Count,
+		interval: Interval,
+		contexts: []*types.Context{},
+		heights:  []uint32{},
+		flushC:   make(chan struct{}, 1),
+		closeC:   make(chan struct{}),
+	}
+	cn.batch = bm
+	bm.wg.Add(1)
+	go cn.runBatchFlusher(bm)
+	return nil
+}
+
+// FlushBlocks stores pending blocks of the batch mode
+func (cn *Chain) FlushBlocks() error {
+	cn.closeLock.RLock()
+	defer cn.closeLock.RUnlock()
+	if cn.isClose {
+		return ErrChainClosed
+	}
+
+	cn.Lock()
+	defer cn.Unlock()
+
+	if cn.batch == nil {
+		return ErrBatchModeDisabled
+	}
+	return cn.store.FlushBlocks()
+}
+
+func (cn *Chain) runBatchFlusher(bm *batchMode) {
+	defer bm.wg.Done()
+
+	var tickC <-chan time.Time
+	if bm.interval > 0 {
+		ticker := time.NewTicker(bm.interval)
+		defer ticker.Stop()
+		tickC = ticker.C
+	}
+	for {
+		select {
+		case <-bm.closeC:
+			return
+		case <-bm.flushC:
+		case <-tickC:
+		}
+		if err := cn.store.FlushBlocks(); err != nil {
+			log.Println("Chain failed to flush blocks", err)
+			bm.setError(err)
+			return
+		}
+	}
+}
+
+// stopBatchMode stops the flusher and flushes remained blocks (should be called with the lock)
+func (cn *Chain) stopBatchMode() error {
+	bm := cn.batch
+	close(bm.closeC)
+	bm.wg.Wait()
+	cn.batch = nil
+	if err := bm.lastError(); err != nil {
+		return err
+	}
+	return cn.store.FlushBlocks()
+}
+
+// newContext returns the context on the last pending block in the batch mode (should be called with the lock)
+func (cn *Chain) newContext() *types.Context {
+	bm := cn.batch
+	if bm == nil {
+		return types.NewContext(cn.store)
+	}
+
+	// contexts of flushed blocks are released and the next one loads the state from the store
+	FlushedHeight := cn.store.FlushedHeight()
+	i := 0
+	for i < len(bm.heights) && bm.heights[i] <= FlushedHeight {
+		i++
+	}
+	if i > 0 {
+		bm.contexts = bm.contexts[i:]
+		bm.heights = bm.heights[i:]
+		if len(bm.contexts) > 0 {
+			bm.contexts[0].Rebase(cn.store)
+		}
+	}
+	if len(bm.contexts) == 0 {
+		return types.NewContext(cn.store)
+	}
+	return bm.contexts[len(bm.contexts)-1].NextContext(cn.store.LastHash(), cn.store.LastTimestamp())
+}
+
+// pushBatchBlock appends the block to pending blocks and triggers the flush (should be called with the lock)
+func (cn *Chain) pushBatchBlock(b *types.Block, ctx *types.Context) error {
+	bm := cn.batch
+	if err := bm.lastError(); err != nil {
+		return err
+	}
+	if err := cn.store.PushBlock(b, ctx.Top()); err != nil {
+		return err
+	}
+	bm.contexts = append(bm.contexts, ctx)
+	bm.heights = append(bm.heights, b.Header.Height)
+
+	PendingCount := cn.store.PendingCount()
+	if PendingCount >= bm.count*4 {
+		// the flusher is behind the execution
+		return cn.store.FlushBlocks()
+	} else if PendingCount >= bm.count {
+		select {
+		case bm.flushC <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+type batchTestApp struct {
+	types.ApplicationBase
+}
+
+func (app *batchTestApp) Name() string {
+	return "fleta.batchtest"
+}
+
+func (app *batchTestApp) Version() string {
+	return "0.0.1"
+}
+
+func (app *batchTestApp) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	return nil
+}
+
+// AfterExecuteTransactions updates the counter which is loaded from the previous block
+func (app *batchTestApp) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	var count uint32
+	if bs := ctw.ProcessData([]byte("count")); len(bs) > 0 {
+		count = binutil.LittleEndian.Uint32(bs)
+	}
+	ctw.SetProcessData([]byte("count"), binutil.LittleEndian.Uint32ToBytes(count+1))
+	ctw.SetProcessData(binutil.LittleEndian.Uint32ToBytes(b.Header.Height), binutil.LittleEndian.Uint32ToBytes(count))
+	return nil
+}
+
+type batchTestConsensus struct {
+	ConsensusBase
+	ct Committer
+}
+
+func (cs *batchTestConsensus) Init(cn *Chain, ct Committer) error {
+	cs.ct = ct
+	return nil
+}
+
+func openBatchTestChain(t *testing.T, dir string) (*Chain, *batchTestConsensus) {
+	st := openTestStoreWith(t, "memory", dir)
+	cs := &batchTestConsensus{}
+	cn := NewChain(cs, &batchTestApp{}, st)
+	if err := cn.Init(hash.Hash256{}, hash.Hash256{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	return cn, cs
+}
+
+func generateBatchTestBlock(ct Committer) (*types.Block, error) {
+	ctx := ct.NewContext()
+	LevelRootHash, err := BuildLevelRoot([]hash.Hash256{ctx.LastHash()})
+	if err != nil {
+		return nil, err
+	}
+	b := &types.Block{
+		Header: types.Header{
+			ChainID:       0xFF,
+			Version:       0x0001,
+			Height:        ctx.TargetHeight(),
+			PrevHash:      ctx.LastHash(),
+			LevelRootHash: LevelRootHash,
+			Timestamp:     ctx.LastTimestamp() + 1,
+			Generator:     common.Address{1},
+		},
+		TransactionTypes:      []uint16{},
+		Transactions:          []types.Transaction{},
+		TransactionSignatures: [][]common.Signature{},
+		Signatures:            []common.Signature{},
+	}
+	if err := ct.ExecuteBlockOnContext(b, ctx, nil); err != nil {
+		return nil, err
+	}
+	b.Header.ContextHash = ctx.Hash()
+	if err := ct.ConnectBlockWithContext(b, ctx); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func checkBatchTestChain(t *testing.T, st *Store, Height uint32) {
+	if st.Height() != Height || st.FlushedHeight() != Height || st.cdb.Height() != Height {
+		t.Fatal("invalid heights", st.Height(), st.FlushedHeight(), st.cdb.Height(), Height)
+	}
+	if v := st.ProcessData(255, []byte("count")); binutil.LittleEndian.Uint32(v) != Height {
+		t.Fatal("invalid count", binutil.LittleEndian.Uint32(v), Height)
+	}
+	for h := uint32(1); h <= Height; h++ {
+		if v := st.ProcessData(255, binutil.LittleEndian.Uint32ToBytes(h)); binutil.LittleEndian.Uint32(v) != h-1 {
+			t.Fatal("invalid count of the height", h, binutil.LittleEndian.Uint32(v))
+		}
+	}
+}
+
+func TestBatchMode(t *testing.T) {
+	dirs := []string{}
+	for i := 0; i < 2; i++ {
+		dir, err := ioutil.TempDir("", "fleta_batch_")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		dirs = append(dirs, dir)
+	}
+
+	cnA, csA := openBatchTestChain(t, dirs[0])
+	defer cnA.Close()
+	blocks := []*types.Block{}
+	for h := uint32(1); h <= 20; h++ {
+		b, err := generateBatchTestBlock(csA.ct)
+		if err != nil {
+			t.Fatal(h, err)
+		}
+		blocks = append(blocks, b)
+	}
+	checkBatchTestChain(t, cnA.store, 20)
+
+	// blocks are executed on chained contexts and the context hash should be same with the direct execution
+	cnB, csB := openBatchTestChain(t, dirs[1])
+	if err := cnB.SetBatchMode(3, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range blocks {
+		if err := cnB.ConnectBlock(b, nil); err != nil {
+			t.Fatal(b.Header.Height, err)
+		}
+		if cnB.Provider().Height() != b.Header.Height {
+			t.Fatal("invalid height", cnB.Provider().Height(), b.Header.Height)
+		}
+		if h, err := cnB.Provider().Hash(b.Header.Height); err != nil {
+			t.Fatal(err)
+		} else if h != encoding.Hash(b.Header) {
+			t.Fatal("invalid hash", b.Header.Height)
+		}
+	}
+	if err := cnB.FlushBlocks(); err != nil {
+		t.Fatal(err)
+	}
+	checkBatchTestChain(t, cnB.store, 20)
+	for h := uint32(1); h <= 20; h++ {
+		ha, err := cnA.store.Hash(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hb, err := cnB.store.Hash(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ha != hb {
+			t.Fatal("block hash is not matched", h)
+		}
+	}
+
+	// blocks are generated on the context of pending blocks
+	for h := uint32(21); h <= 30; h++ {
+		if _, err := generateBatchTestBlock(csB.ct); err != nil {
+			t.Fatal(h, err)
+		}
+	}
+	if err := cnB.SetBatchMode(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	checkBatchTestChain(t, cnB.store, 30)
+	cnB.Close()
+
+	st := openTestStoreWith(t, "memory", dirs[1])
+	defer st.Close()
+	checkBatchTestChain(t, st, 30)
+}
@@ -50,5 +50,5 @@ func (ct *chainCommiter) NewContext() *types.Context {
 	ct.cn.Lock()
 	defer ct.cn.Unlock()
 
-	return types.NewContext(ct.cn.store)
+	return ct.cn.newContext()
 }
@@ -30,4 +30,13 @@ var (
 	ErrFoundForkedBlock             = errors.New("found forked block")
 	ErrCannotDeleteGeneratorAccount = errors.New("cannot delete generator account")
 	ErrInvalidAccountName           = errors.New("invalid account name")
+	ErrInvalidCommitJournal         = errors.New("invalid commit journal")
+	ErrPileHashMismatch             = errors.New("pile hash mismatch")
+	ErrPileBehindState              = errors.New("pile behind state")
+	ErrInvalidDataHash              = errors.New("invalid data hash")
+	ErrStateMismatch                = errors.New("state mismatch")
+	ErrInvalidLimit                 = errors.New("invalid limit")
+	ErrPendingBlocks                = errors.New("pending blocks")
+	ErrBatchModeDisabled            = errors.New("batch mode disabled")
+	ErrReadOnlyStore                = errors.New("read-only store")
 )
@@ -14,6 +14,26 @@ import (
 	"github.com/fletaio/fleta/encoding"
 )
 
+func init() {
+	backend.RegisterTypedUnmarshaler(tagAccount, decodeAccount)
+}
+
+// decodeAccount decodes the stored value of the account
+func decodeAccount(value []byte) (interface{}, error) {
+	if len(value) == 1 && value[0] == 0 {
+		return nil, types.ErrDeletedAccount
+	}
+	fc := encoding.Factory("account")
+	v, err := fc.Create(binutil.LittleEndian.Uint16(value))
+	if err != nil {
+		return nil, err
+	}
+	if err := encoding.Unmarshal(value[2:], &v); err != nil {
+		return nil, err
+	}
+	return v.(types.Account), nil
+}
+
 // Store saves the target chain state
 // All updates are executed in one transaction with FileSync option
 type Store struct {
@@ -30,6 +50,11 @@ type Store struct {
 	isClose      bool
 	timeSlotMap  map[uint32]map[string]bool
 	timeSlotLock sync.Mutex
+	pending      []*pendingBlock
+	pendingLock  sync.RWMutex
+	readOnly     bool
+	stateCache   *stateCache
+	resetHeight  uint32
 }
 
 type storecache struct {
@@ -50,8 +75,12 @@ func NewStore(db backend.StoreBackend, cdb *pile.DB, ChainID uint8, symbol strin
 		usage:       usage,
 		version:     version,
 		timeSlotMap: map[uint32]map[string]bool{},
+		stateCache:  newStateCache(DefaultCacheSize),
 	}
 	st.setupMagicNumber()
+	if err := st.recoverCommit(); err != nil {
+		return nil, err
+	}
 
 	go func() {
 		for !st.isClose {
@@ -109,6 +138,17 @@ func (st *Store) Version() uint16 {
 	return st.version
 }
 
+// SetKeyResetHeight sets the height from which a key set by a snapshot clears the deleted mark of the key
+// it should be the same in all nodes of the chain because it changes the context hash
+func (st *Store) SetKeyResetHeight(Height uint32) {
+	st.resetHeight = Height
+}
+
+// KeyResetHeight returns the height from which a key set by a snapshot clears the deleted mark of the key
+func (st *Store) KeyResetHeight() uint32 {
+	return st.resetHeight
+}
+
 // TargetHeight returns the target height of the target chain
 func (st *Store) TargetHeight() uint32 {
 	return st.Height() + 1
@@ -231,6 +271,9 @@ func (st *Store) Hash(height uint32) (hash.Hash256, error) {
 			return st.cache.heightHash, nil
 		}
 	}
+	if pb := st.pendingBlock(height); pb != nil {
+		return pb.DataHash, nil
+	}
 
 	h, err := st.cdb.GetHash(height)
 	if err != nil {
@@ -259,6 +302,9 @@ func (st *Store) Header(height uint32) (*types.Header, error) {
 			return &st.cache.heightBlock.Header, nil
 		}
 	}
+	if pb := st.pendingBlock(height); pb != nil {
+		return &pb.Block.Header, nil
+	}
 
 	value, err := st.cdb.GetData(height, 0)
 	if err != nil {
@@ -291,6 +337,9 @@ func (st *Store) Block(height uint32) (*types.Block, error) {
 			return st.cache.heightBlock, nil
 		}
 	}
+	if pb := st.pendingBlock(height); pb != nil {
+		return pb.Block, nil
+	}
 
 	value, err := st.cdb.GetDatas(height, 0, 2)
 	if err != nil {
@@ -386,6 +435,55 @@ func (st *Store) Accounts() ([]types.Account, error) {
 	return list, nil
 }
 
+// AccountsPage returns accounts from the cursor in the key order and the cursor of the next page
+// the cursor is the address of the first account of the page, nil cursor means the first page and nil next cursor means the last page
+func (st *Store) AccountsPage(Cursor []byte, Limit int, Reverse bool) ([]types.Account, []byte, error) {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return nil, nil, ErrStoreClosed
+	}
+	if Limit <= 0 {
+		return nil, nil, ErrInvalidLimit
+	}
+
+	opt := &backend.IterateOption{
+		Prefix:  tagAccount,
+		Reverse: Reverse,
+	}
+	if len(Cursor) > 0 {
+		opt.From = append(append([]byte{}, tagAccount...), Cursor...)
+	}
+	fc := encoding.Factory("account")
+	list := []types.Account{}
+	var next []byte
+	if err := st.db.View(func(txn backend.StoreReader) error {
+		if err := txn.IterateRange(opt, func(key []byte, value []byte) error {
+			if len(value) > 1 {
+				if len(list) >= Limit {
+					next = append([]byte{}, key[len(tagAccount):]...)
+					return backend.ErrStopIteration
+				}
+				acc, err := fc.Create(binutil.LittleEndian.Uint16(value))
+				if err != nil {
+					return err
+				}
+				if err := encoding.Unmarshal(value[2:], &acc); err != nil {
+					return err
+				}
+				list = append(list, acc.(types.Account))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return list, next, nil
+}
+
 // Account returns the account instance of the address from the store
 func (st *Store) Account(addr common.Address) (types.Account, error) {
 	st.closeLock.RLock()
@@ -394,28 +492,42 @@ func (st *Store) Account(addr common.Address) (types.Account, error) {
 		return nil, ErrStoreClosed
 	}
 
-	fc := encoding.Factory("account")
+	key := toAccountKey(addr)
+	cached, has, epoch := st.stateCache.get(key)
+	if has {
+		return cachedAccount(cached)
+	}
 
 	var acc types.Account
 	if err := st.db.View(func(txn backend.StoreReader) error {
-		value, err := txn.Get(toAccountKey(addr))
+		if tr, is := txn.(backend.TypedReader); is {
+			v, size, err := tr.GetTyped(key)
+			if err == nil {
+				acc = v.(types.Account).Clone()
+				st.stateCache.put(key, acc.Clone(), size, epoch)
+				return nil
+			} else if err != backend.ErrNotTypedValue {
+				return err
+			}
+		}
+		value, err := txn.Get(key)
 		if err != nil {
 			return err
 		}
 		if len(value) == 1 && value[0] == 0 {
+			st.stateCache.put(key, deletedAccount{}, 0, epoch)
 			return types.ErrDeletedAccount
 		}
-		v, err := fc.Create(binutil.LittleEndian.Uint16(value))
+		v, err := decodeAccount(value)
 		if err != nil {
 			return err
 		}
-		if err := encoding.Unmarshal(value[2:], &v); err != nil {
-			return err
-		}
 		acc = v.(types.Account)
+		st.stateCache.put(key, acc.Clone(), len(value), epoch)
 		return nil
 	}); err != nil {
 		if err == backend.ErrNotExistKey {
+			st.stateCache.put(key, nil, 0, epoch)
 			return nil, types.ErrNotExistAccount
 		} else {
 			return nil, err
@@ -467,6 +579,17 @@ func (st *Store) HasAccount(addr common.Address) (bool, error) {
 		return false, ErrStoreClosed
 	}
 
+	if cached, has, _ := st.stateCache.get(toAccountKey(addr)); has {
+		switch cached.(type) {
+		case nil:
+			return false, nil
+		case deletedAccount:
+			return false, types.ErrDeletedAccount
+		default:
+			return true, nil
+		}
+	}
+
 	var Has bool
 	if err := st.db.View(func(txn backend.StoreReader) error {
 		value, err := txn.Get(toAccountKey(addr))
@@ -537,10 +660,15 @@ func (st *Store) AccountData(addr common.Address, pid uint8, name []byte) []byte
 		return nil
 	}
 
-	key := string(addr[:]) + string(pid) + string(name)
+	key := toAccountDataKey(string(addr[:]) + string(pid) + string(name))
+	cached, has, epoch := st.stateCache.get(key)
+	if has {
+		return cachedData(cached)
+	}
+
 	var data []byte
 	if err := st.db.View(func(txn backend.StoreReader) error {
-		value, err := txn.Get(toAccountDataKey(key))
+		value, err := txn.Get(key)
 		if err != nil {
 			return err
 		}
@@ -548,8 +676,12 @@ func (st *Store) AccountData(addr common.Address, pid uint8, name []byte) []byte
 		copy(data, value)
 		return nil
 	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			st.stateCache.put(key, nil, 0, epoch)
+		}
 		return nil
 	}
+	st.stateCache.put(key, cachedData(data), len(data), epoch)
 	return data
 }
 
@@ -583,6 +715,53 @@ func (st *Store) UTXOs() ([]*types.UTXO, error) {
 	return list, nil
 }
 
+// UTXOsPage returns utxos from the cursor in the id order and the cursor of the next page
+// the cursor is the big endian id of the first utxo of the page, nil cursor means the first page and nil next cursor means the last page
+func (st *Store) UTXOsPage(Cursor []byte, Limit int, Reverse bool) ([]*types.UTXO, []byte, error) {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return nil, nil, ErrStoreClosed
+	}
+	if Limit <= 0 {
+		return nil, nil, ErrInvalidLimit
+	}
+
+	opt := &backend.IterateOption{
+		Prefix:  tagUTXO,
+		Reverse: Reverse,
+		Limit:   Limit + 1,
+	}
+	if len(Cursor) > 0 {
+		opt.From = append(append([]byte{}, tagUTXO...), Cursor...)
+	}
+	list := []*types.UTXO{}
+	var next []byte
+	if err := st.db.View(func(txn backend.StoreReader) error {
+		if err := txn.IterateRange(opt, func(key []byte, value []byte) error {
+			if len(list) >= Limit {
+				next = append([]byte{}, key[len(tagUTXO):]...)
+				return nil
+			}
+			utxo := &types.UTXO{
+				TxIn:  types.NewTxIn(fromUTXOKey(key)),
+				TxOut: types.NewTxOut(),
+			}
+			if err := encoding.Unmarshal(value, &(utxo.TxOut)); err != nil {
+				return err
+			}
+			list = append(list, utxo)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return list, next, nil
+}
+
 // HasUTXO bhecks that the utxo of the id is exist or not
 func (st *Store) HasUTXO(id uint64) (bool, error) {
 	st.closeLock.RLock()
@@ -649,10 +828,15 @@ func (st *Store) ProcessData(pid uint8, name []byte) []byte {
 		return nil
 	}
 
-	key := string(pid) + string(name)
+	key := toProcessDataKey(string(pid) + string(name))
+	cached, has, epoch := st.stateCache.get(key)
+	if has {
+		return cachedData(cached)
+	}
+
 	var data []byte
 	if err := st.db.View(func(txn backend.StoreReader) error {
-		value, err := txn.Get(toProcessDataKey(key))
+		value, err := txn.Get(key)
 		if err != nil {
 			return err
 		}
@@ -660,8 +844,12 @@ func (st *Store) ProcessData(pid uint8, name []byte) []byte {
 		copy(data, value)
 		return nil
 	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			st.stateCache.put(key, nil, 0, epoch)
+		}
 		return nil
 	}
+	st.stateCache.put(key, cachedData(data), len(data), epoch)
 	return data
 }
 
@@ -731,6 +919,9 @@ func (st *Store) StoreGenesis(genHash hash.Hash256, ctd *types.ContextData) erro
 	if st.isClose {
 		return ErrStoreClosed
 	}
+	if st.readOnly {
+		return ErrReadOnlyStore
+	}
 
 	st.Lock()
 	defer st.Unlock()
@@ -743,7 +934,8 @@ func (st *Store) StoreGenesis(genHash hash.Hash256, ctd *types.ContextData) erro
 			return err
 		}
 	}
-	if err := st.db.Update(func(txn backend.StoreWriter) error {
+	var updated [][]byte
+	err := st.db.Update(func(txn backend.StoreWriter) error {
 		{
 			if err := txn.Set(toHeightHashKey(0), genHash[:]); err != nil {
 				return err
@@ -753,11 +945,15 @@ func (st *Store) StoreGenesis(genHash hash.Hash256, ctd *types.ContextData) erro
 				return err
 			}
 		}
-		if err := applyContextData(txn, ctd); err != nil {
+		keys, err := applyContextData(txn, ctd)
+		if err != nil {
 			return err
 		}
+		updated = keys
 		return nil
-	}); err != nil {
+	})
+	st.stateCache.invalidate(updated)
+	if err != nil {
 		return err
 	}
 	st.cache.height = 0
@@ -775,6 +971,9 @@ func (st *Store) StoreInit(genHash hash.Hash256, initHash hash.Hash256, initHeig
 	if st.isClose {
 		return ErrStoreClosed
 	}
+	if st.readOnly {
+		return ErrReadOnlyStore
+	}
 
 	if st.Height() > initHeight {
 		return ErrAlreadyInitialzed
@@ -813,29 +1012,90 @@ func (st *Store) StoreInit(genHash hash.Hash256, initHash hash.Hash256, initHeig
 }
 
 // StoreBlock stores the block
+// the state is committed with the journal of the block before the pile is appended
 func (st *Store) StoreBlock(b *types.Block, ctd *types.ContextData) error {
 	st.closeLock.RLock()
 	defer st.closeLock.RUnlock()
 	if st.isClose {
 		return ErrStoreClosed
 	}
+	if st.readOnly {
+		return ErrReadOnlyStore
+	}
 
 	st.Lock()
 	defer st.Unlock()
 
+	if st.PendingCount() > 0 {
+		return ErrPendingBlocks
+	}
+	DataHash, Datas, err := blockDatas(b, ctd)
+	if err != nil {
+		return err
+	}
+	if err := st.checkPileHash(b.Header.Height, DataHash); err != nil {
+		return err
+	}
+	journal, err := encoding.Marshal(&commitJournal{
+		Height:   b.Header.Height,
+		DataHash: DataHash,
+		Datas:    Datas,
+	})
+	if err != nil {
+		return err
+	}
+	callCommitHook(stepBeforeState)
+	var updated [][]byte
+	err = st.db.Update(func(txn backend.StoreWriter) error {
+		{
+			bsHeight := binutil.LittleEndian.Uint32ToBytes(b.Header.Height)
+			if err := txn.Set(tagHeight, bsHeight); err != nil {
+				return err
+			}
+		}
+		if err := txn.Set(tagCommitJournal, journal); err != nil {
+			return err
+		}
+		if err := txn.Delete(tagCommitBatch); err != nil {
+			return err
+		}
+		keys, err := applyContextData(txn, ctd)
+		if err != nil {
+			return err
+		}
+		updated = keys
+		return nil
+	})
+	st.stateCache.invalidate(updated)
+	if err != nil {
+		return err
+	}
+	callCommitHook(stepAfterState)
+	if err := st.appendPile(b.Header.Height, DataHash, Datas); err != nil {
+		return err
+	}
+	callCommitHook(stepAfterPile)
+
+	st.updateTimeSlots(ctd, b.Header.Timestamp)
+	st.updateCache(b, DataHash)
+	return nil
+}
+
+// blockDatas returns the hash and the pile entries of the block
+func blockDatas(b *types.Block, ctd *types.ContextData) (hash.Hash256, [][]byte, error) {
 	DataHash := encoding.Hash(b.Header)
 	Datas := [][]byte{}
 	{
 		data, err := encoding.Marshal(b.Header)
 		if err != nil {
-			return err
+			return hash.Hash256{}, nil, err
 		}
 		Datas = append(Datas, data)
 	}
 	{
 		data, err := encoding.Marshal(b)
 		if err != nil {
-			return err
+			return hash.Hash256{}, nil, err
 		}
 		Datas = append(Datas, data[len(Datas[0]):]) // cut header data
 	}
@@ -844,42 +1104,26 @@ func (st *Store) StoreBlock(b *types.Block, ctd *types.ContextData) error {
 		efc := encoding.Factory("event")
 		enc := encoding.NewEncoder(&buffer)
 		if err := enc.EncodeArrayLen(len(ctd.Events)); err != nil {
-			return err
+			return hash.Hash256{}, nil, err
 		}
 		for _, ev := range ctd.Events {
 			t, err := efc.TypeOf(ev)
 			if err != nil {
-				return err
+				return hash.Hash256{}, nil, err
 			}
 			if err := enc.EncodeUint16(t); err != nil {
-				return err
+				return hash.Hash256{}, nil, err
 			}
 			if err := enc.Encode(ev); err != nil {
-				return err
+				return hash.Hash256{}, nil, err
 			}
 		}
 		Datas = append(Datas, buffer.Bytes())
 	}
-	if err := st.cdb.AppendData(b.Header.Height, DataHash, Datas); err != nil {
-		if err != pile.ErrInvalidAppendHeight {
-			return err
-		}
-	}
-	if err := st.db.Update(func(txn backend.StoreWriter) error {
-		{
-			bsHeight := binutil.LittleEndian.Uint32ToBytes(b.Header.Height)
-			if err := txn.Set(tagHeight, bsHeight); err != nil {
-				return err
-			}
-		}
-		if err := applyContextData(txn, ctd); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
-		return err
-	}
+	return DataHash, Datas, nil
+}
 
+func (st *Store) updateTimeSlots(ctd *types.ContextData, Timestamp uint64) {
 	st.timeSlotLock.Lock()
 	ctd.TimeSlotMap.EachAll(func(key uint32, mp *types.StringBoolMap) bool {
 		smp, has := st.timeSlotMap[key]
@@ -893,7 +1137,7 @@ func (st *Store) StoreBlock(b *types.Block, ctd *types.ContextData) error {
 		})
 		return true
 	})
-	currentSlot := types.ToTimeSlot(b.Header.Timestamp)
+	currentSlot := types.ToTimeSlot(Timestamp)
 	deleteSlots := []uint32{}
 	for slot := range st.timeSlotMap {
 		if slot < currentSlot-1 {
@@ -904,13 +1148,14 @@ func (st *Store) StoreBlock(b *types.Block, ctd *types.ContextData) error {
 		delete(st.timeSlotMap, v)
 	}
 	st.timeSlotLock.Unlock()
+}
 
+func (st *Store) updateCache(b *types.Block, DataHash hash.Hash256) {
 	st.cache.height = b.Header.Height
 	st.cache.heightHash = DataHash
 	st.cache.heightBlock = b
 	st.cache.heightTimestamp = b.Header.Timestamp
 	st.cache.cached = true
-	return nil
 }
 
 func (st *Store) IterBlockAfterContext(fn func(b *types.Block) error) error {
@@ -930,7 +1175,9 @@ func (st *Store) IterBlockAfterContext(fn func(b *types.Block) error) error {
 	return nil
 }
 
-func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
+// applyContextData writes the context data to the store and returns the updated keys which can be cached
+func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) ([][]byte, error) {
+	keys := [][]byte{}
 	var inErr error
 	afc := encoding.Factory("account")
 	ctd.AccountMap.EachAll(func(addr common.Address, acc types.Account) bool {
@@ -947,10 +1194,12 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 			return false
 		}
 		buffer.Write(data)
-		if err := txn.Set(toAccountKey(addr), buffer.Bytes()); err != nil {
+		key := toAccountKey(addr)
+		if err := txn.Set(key, buffer.Bytes()); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, key)
 		if err := txn.Set(toAccountNameKey(acc.Name()), addr[:]); err != nil {
 			inErr = err
 			return false
@@ -958,23 +1207,27 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.AccountDataMap.EachAll(func(key string, value []byte) bool {
-		if err := txn.Set(toAccountDataKey(key), value); err != nil {
+		dkey := toAccountDataKey(key)
+		if err := txn.Set(dkey, value); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, dkey)
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.DeletedAccountMap.EachAll(func(addr common.Address, acc types.Account) bool {
-		if err := txn.Set(toAccountKey(addr), []byte{0}); err != nil {
+		key := toAccountKey(addr)
+		if err := txn.Set(key, []byte{0}); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, key)
 		prefix := toAccountDataKey(string(addr[:]))
 		Deletes := [][]byte{}
 		if err := txn.Iterate(prefix, func(key []byte, value []byte) error {
@@ -989,21 +1242,24 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 				inErr = err
 				return false
 			}
+			keys = append(keys, v)
 		}
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.DeletedAccountDataMap.EachAll(func(key string, value bool) bool {
-		if err := txn.Delete(toAccountDataKey(key)); err != nil {
+		dkey := toAccountDataKey(key)
+		if err := txn.Delete(dkey); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, dkey)
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.UTXOMap.EachAll(func(id uint64, utxo *types.UTXO) bool {
 		if utxo.TxIn.ID() != id {
@@ -1022,7 +1278,7 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.CreatedUTXOMap.EachAll(func(id uint64, vout *types.TxOut) bool {
 		data, err := encoding.Marshal(vout)
@@ -1037,7 +1293,7 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.DeletedUTXOMap.EachAll(func(id uint64, utxo *types.UTXO) bool {
 		if err := txn.Delete(toUTXOKey(id)); err != nil {
@@ -1047,29 +1303,33 @@ func applyContextData(txn backend.StoreWriter, ctd *types.ContextData) error {
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.ProcessDataMap.EachAll(func(key string, value []byte) bool {
-		if err := txn.Set(toProcessDataKey(key), value); err != nil {
+		pkey := toProcessDataKey(key)
+		if err := txn.Set(pkey, value); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, pkey)
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
 	ctd.DeletedProcessDataMap.EachAll(func(key string, value bool) bool {
-		if err := txn.Delete(toProcessDataKey(key)); err != nil {
+		pkey := toProcessDataKey(key)
+		if err := txn.Delete(pkey); err != nil {
 			inErr = err
 			return false
 		}
+		keys = append(keys, pkey)
 		return true
 	})
 	if inErr != nil {
-		return inErr
+		return nil, inErr
 	}
-	return nil
+	return keys, nil
 }
 
 func (st *Store) InitTimeSlot() error {
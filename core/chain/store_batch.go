@@ -0,0 +1,230 @@
+package chain
+
+import (
+	"log"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// pendingBlock is the executed block which is not flushed to the disk yet
+type pendingBlock struct {
+	Block    *types.Block
+	DataHash hash.Hash256
+	Datas    [][]byte
+	ctd      *types.ContextData
+}
+
+// commitBatchJournal is the pile entries of the flushed blocks which are stored with the state
+// it is removed after the pile is synced
+type commitBatchJournal struct {
+	Journals []*commitJournal
+}
+
+// PushBlock appends the executed block to the pending blocks
+// heights and hashes of pending blocks are provided by the store but the state is provided after FlushBlocks
+func (st *Store) PushBlock(b *types.Block, ctd *types.ContextData) error {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return ErrStoreClosed
+	}
+	if st.readOnly {
+		return ErrReadOnlyStore
+	}
+
+	if b.Header.Height != st.Height()+1 {
+		return ErrInvalidHeight
+	}
+	DataHash, Datas, err := blockDatas(b, ctd)
+	if err != nil {
+		return err
+	}
+
+	st.pendingLock.Lock()
+	st.pending = append(st.pending, &pendingBlock{
+		Block:    b,
+		DataHash: DataHash,
+		Datas:    Datas,
+		ctd:      ctd,
+	})
+	st.pendingLock.Unlock()
+
+	st.updateTimeSlots(ctd, b.Header.Timestamp)
+	st.updateCache(b, DataHash)
+	return nil
+}
+
+// PendingCount returns the number of pending blocks
+func (st *Store) PendingCount() int {
+	st.pendingLock.RLock()
+	defer st.pendingLock.RUnlock()
+
+	return len(st.pending)
+}
+
+// FlushedHeight returns the height of the state which is stored in the backend
+func (st *Store) FlushedHeight() uint32 {
+	st.pendingLock.RLock()
+	defer st.pendingLock.RUnlock()
+
+	if len(st.pending) > 0 {
+		return st.pending[0].Block.Header.Height - 1
+	}
+	return st.Height()
+}
+
+func (st *Store) pendingBlock(height uint32) *pendingBlock {
+	st.pendingLock.RLock()
+	defer st.pendingLock.RUnlock()
+
+	if len(st.pending) == 0 {
+		return nil
+	}
+	First := st.pending[0].Block.Header.Height
+	if height < First || height >= First+uint32(len(st.pending)) {
+		return nil
+	}
+	return st.pending[height-First]
+}
+
+// FlushBlocks stores pending blocks by one transaction of the backend and one sync of the pile
+// the state is committed with the journal of blocks, so the pile is recovered to the flushed height after a crash
+func (st *Store) FlushBlocks() error {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return ErrStoreClosed
+	}
+	if st.readOnly {
+		return ErrReadOnlyStore
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	st.pendingLock.RLock()
+	list := make([]*pendingBlock, len(st.pending))
+	copy(list, st.pending)
+	st.pendingLock.RUnlock()
+	if len(list) == 0 {
+		return nil
+	}
+
+	batch := &commitBatchJournal{
+		Journals: make([]*commitJournal, 0, len(list)),
+	}
+	for _, pb := range list {
+		if err := st.checkPileHash(pb.Block.Header.Height, pb.DataHash); err != nil {
+			return err
+		}
+		batch.Journals = append(batch.Journals, &commitJournal{
+			Height:   pb.Block.Header.Height,
+			DataHash: pb.DataHash,
+			Datas:    pb.Datas,
+		})
+	}
+	journal, err := encoding.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	Height := list[len(list)-1].Block.Header.Height
+
+	callCommitHook(stepBeforeState)
+	var updated [][]byte
+	err = st.db.Update(func(txn backend.StoreWriter) error {
+		{
+			bsHeight := binutil.LittleEndian.Uint32ToBytes(Height)
+			if err := txn.Set(tagHeight, bsHeight); err != nil {
+				return err
+			}
+		}
+		if err := txn.Set(tagCommitBatch, journal); err != nil {
+			return err
+		}
+		if err := txn.Delete(tagCommitJournal); err != nil {
+			return err
+		}
+		for _, pb := range list {
+			keys, err := applyContextData(txn, pb.ctd)
+			if err != nil {
+				return err
+			}
+			updated = append(updated, keys...)
+		}
+		return nil
+	})
+	st.stateCache.invalidate(updated)
+	if err != nil {
+		return err
+	}
+	callCommitHook(stepAfterState)
+	for _, pb := range list {
+		if st.cdb.Height() >= pb.Block.Header.Height {
+			continue
+		}
+		if err := st.cdb.AppendDataWithoutSync(pb.Block.Header.Height, pb.DataHash, pb.Datas); err != nil {
+			return err
+		}
+	}
+	if err := st.cdb.Sync(); err != nil {
+		return err
+	}
+	callCommitHook(stepAfterPile)
+	if err := st.db.Update(func(txn backend.StoreWriter) error {
+		return txn.Delete(tagCommitBatch)
+	}); err != nil {
+		return err
+	}
+
+	st.pendingLock.Lock()
+	st.pending = st.pending[len(list):]
+	st.pendingLock.Unlock()
+	return nil
+}
+
+// recoverBatch rewrites the pile from the batch journal because the pile can have unsynced entries of the batch
+func (st *Store) recoverBatch(Height uint32, value []byte) error {
+	var batch commitBatchJournal
+	if err := encoding.Unmarshal(value, &batch); err != nil {
+		return err
+	}
+	if len(batch.Journals) == 0 {
+		return ErrInvalidCommitJournal
+	}
+	First := batch.Journals[0].Height
+	for i, j := range batch.Journals {
+		if j.Height != First+uint32(i) {
+			return ErrInvalidCommitJournal
+		}
+	}
+	if batch.Journals[len(batch.Journals)-1].Height != Height {
+		return ErrInvalidCommitJournal
+	}
+	if st.cdb.Height()+1 < First {
+		return ErrPileBehindState
+	}
+	if st.cdb.Height() >= First {
+		if err := st.cdb.Truncate(First - 1); err != nil {
+			return err
+		}
+	}
+	for _, j := range batch.Journals {
+		if err := st.cdb.AppendDataWithoutSync(j.Height, j.DataHash, j.Datas); err != nil {
+			return err
+		}
+	}
+	if err := st.cdb.Sync(); err != nil {
+		return err
+	}
+	if err := st.db.Update(func(txn backend.StoreWriter) error {
+		return txn.Delete(tagCommitBatch)
+	}); err != nil {
+		return err
+	}
+	log.Println("Store recovered blocks from", First, "to", Height, "from the batch journal")
+	return nil
+}
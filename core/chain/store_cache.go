@@ -0,0 +1,197 @@
+package chain
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/fletaio/fleta/core/types"
+)
+
+// DefaultCacheSize is the default memory budget of the state cache in bytes
+const DefaultCacheSize = 64 << 20
+
+// cacheItemOverhead approximates the bookkeeping bytes of a cached item
+const cacheItemOverhead = 96
+
+// CacheStats is the statistics of the state cache of the store
+type CacheStats struct {
+	Budget    int64
+	Size      int64
+	Count     int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns the ratio of hits in all lookups
+func (cs CacheStats) HitRate() float64 {
+	total := cs.Hits + cs.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(cs.Hits) / float64(total)
+}
+
+// deletedAccount marks the account that is deleted in the store
+type deletedAccount struct{}
+
+// stateCache is a LRU cache of accounts, account datas and process datas
+// Items are keyed by the store key and a nil value means that the key is not exist
+type stateCache struct {
+	sync.Mutex
+	budget    int64
+	size      int64
+	epoch     uint64
+	list      *list.List
+	items     map[string]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type stateCacheItem struct {
+	key   string
+	value interface{}
+	size  int64
+}
+
+func newStateCache(budget int64) *stateCache {
+	return &stateCache{
+		budget: budget,
+		list:   list.New(),
+		items:  map[string]*list.Element{},
+	}
+}
+
+// get returns the cached value and the current epoch
+// The epoch should be passed to put to prevent storing a value which is read before an update
+func (sc *stateCache) get(key []byte) (interface{}, bool, uint64) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	if e, has := sc.items[string(key)]; has {
+		sc.hits++
+		sc.list.MoveToFront(e)
+		return e.Value.(*stateCacheItem).value, true, sc.epoch
+	}
+	sc.misses++
+	return nil, false, sc.epoch
+}
+
+func (sc *stateCache) put(key []byte, value interface{}, size int, epoch uint64) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	if sc.epoch != epoch {
+		return
+	}
+	item := &stateCacheItem{
+		key:   string(key),
+		value: value,
+		size:  int64(len(key)+size) + cacheItemOverhead,
+	}
+	if item.size > sc.budget {
+		return
+	}
+	if e, has := sc.items[item.key]; has {
+		sc.removeElement(e)
+	}
+	sc.items[item.key] = sc.list.PushFront(item)
+	sc.size += item.size
+	for sc.size > sc.budget {
+		sc.removeElement(sc.list.Back())
+		sc.evictions++
+	}
+}
+
+// invalidate removes updated keys and advances the epoch
+func (sc *stateCache) invalidate(keys [][]byte) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.epoch++
+	for _, key := range keys {
+		if e, has := sc.items[string(key)]; has {
+			sc.removeElement(e)
+		}
+	}
+}
+
+// purge removes all items and advances the epoch
+func (sc *stateCache) purge() {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.epoch++
+	sc.list.Init()
+	sc.items = map[string]*list.Element{}
+	sc.size = 0
+}
+
+func (sc *stateCache) setBudget(budget int64) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.budget = budget
+	for sc.size > sc.budget {
+		sc.removeElement(sc.list.Back())
+		sc.evictions++
+	}
+}
+
+func (sc *stateCache) stats() CacheStats {
+	sc.Lock()
+	defer sc.Unlock()
+
+	return CacheStats{
+		Budget:    sc.budget,
+		Size:      sc.size,
+		Count:     len(sc.items),
+		Hits:      sc.hits,
+		Misses:    sc.misses,
+		Evictions: sc.evictions,
+	}
+}
+
+func (sc *stateCache) removeElement(e *list.Element) {
+	item := sc.list.Remove(e).(*stateCacheItem)
+	delete(sc.items, item.key)
+	sc.size -= item.size
+}
+
+// cachedAccount returns the copy of the cached account
+func cachedAccount(value interface{}) (types.Account, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, types.ErrNotExistAccount
+	case deletedAccount:
+		return nil, types.ErrDeletedAccount
+	default:
+		return v.(types.Account).Clone(), nil
+	}
+}
+
+// cachedData returns the copy of the cached data
+func cachedData(value interface{}) []byte {
+	if value == nil {
+		return nil
+	}
+	bs := value.([]byte)
+	data := make([]byte, len(bs))
+	copy(data, bs)
+	return data
+}
+
+// SetCacheSize changes the memory budget of the state cache in bytes
+// Zero size disables the cache
+func (st *Store) SetCacheSize(size int64) {
+	if size < 0 {
+		size = 0
+	}
+	st.stateCache.setBudget(size)
+}
+
+// CacheStats returns the statistics of the state cache
+func (st *Store) CacheStats() CacheStats {
+	return st.stateCache.stats()
+}
@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func TestStateCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_cache_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	st := openTestStore(t, dir)
+	defer st.Close()
+	if err := st.StoreGenesis(hash.Hash256{}, types.NewContextData(st, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	addr := common.NewAddress(0, 1, 0)
+	storeData := func(Height uint32, value []byte) {
+		b, ctd, err := newTestBlock(st, Height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctd.SetAccountData(addr, 1, []byte("data"), value)
+		if err := st.StoreBlock(b, ctd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkData := func(value []byte) {
+		if bs := st.AccountData(addr, 1, []byte("data")); !bytes.Equal(bs, value) {
+			t.Fatal("invalid account data", bs, value)
+		}
+	}
+
+	checkData(nil)
+	checkData(nil)
+	if cs := st.CacheStats(); cs.Hits != 1 || cs.Misses != 1 {
+		t.Fatal("invalid stats", cs)
+	}
+
+	storeData(1, []byte("first"))
+	checkData([]byte("first"))
+	checkData([]byte("first"))
+
+	bs := st.AccountData(addr, 1, []byte("data"))
+	bs[0] = 'x'
+	checkData([]byte("first"))
+
+	storeData(2, []byte("second"))
+	checkData([]byte("second"))
+	if bs := st.ProcessData(1, []byte("height")); !bytes.Equal(bs, []byte{2, 0, 0, 0}) {
+		t.Fatal("invalid process data", bs)
+	}
+
+	storeData(3, nil)
+	checkData(nil)
+
+	cs := st.CacheStats()
+	if cs.Count == 0 || cs.Size == 0 || cs.HitRate() <= 0 {
+		t.Fatal("invalid stats", cs)
+	}
+
+	st.SetCacheSize(0)
+	if cs := st.CacheStats(); cs.Count != 0 || cs.Size != 0 {
+		t.Fatal("cache is not evicted", cs)
+	}
+	checkData(nil)
+	if cs := st.CacheStats(); cs.Count != 0 {
+		t.Fatal("disabled cache stores an item", cs)
+	}
+}
+
+func TestStateCacheBudget(t *testing.T) {
+	sc := newStateCache(3 * (cacheItemOverhead + 2))
+	for i := 0; i < 4; i++ {
+		_, _, epoch := sc.get([]byte{byte(i)})
+		sc.put([]byte{byte(i)}, []byte{byte(i)}, 1, epoch)
+	}
+	if cs := sc.stats(); cs.Count != 3 || cs.Evictions != 1 {
+		t.Fatal("invalid stats", cs)
+	}
+	if _, has, _ := sc.get([]byte{0}); has {
+		t.Fatal("the oldest item is not evicted")
+	}
+
+	_, _, epoch := sc.get([]byte{9})
+	sc.invalidate([][]byte{{1}})
+	sc.put([]byte{9}, []byte{9}, 1, epoch)
+	if _, has, _ := sc.get([]byte{9}); has {
+		t.Fatal("a value which is read before the update is stored")
+	}
+	if _, has, _ := sc.get([]byte{1}); has {
+		t.Fatal("the updated item is not invalidated")
+	}
+}
@@ -0,0 +1,132 @@
+package chain
+
+import (
+	"log"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// commit steps of StoreBlock
+const (
+	stepBeforeState = iota
+	stepAfterState  = iota
+	stepAfterPile   = iota
+)
+
+// commitHook is called at each commit step (used to inject crashes in tests)
+var commitHook func(step int)
+
+func callCommitHook(step int) {
+	if commitHook != nil {
+		commitHook(step)
+	}
+}
+
+// commitJournal is the pile entry of the last block which is stored with the state
+// the backend is committed before the pile, so the pile can be recovered from the journal
+type commitJournal struct {
+	Height   uint32
+	DataHash hash.Hash256
+	Datas    [][]byte
+}
+
+// checkPileHash checks that the pile has the same data when the pile already has the height
+func (st *Store) checkPileHash(Height uint32, DataHash hash.Hash256) error {
+	if st.cdb.Height() < Height {
+		return nil
+	}
+	h, err := st.cdb.GetHash(Height)
+	if err != nil {
+		return err
+	}
+	if h != DataHash {
+		return ErrPileHashMismatch
+	}
+	return nil
+}
+
+// appendPile appends the data to the pile when the pile doesn't have the height
+func (st *Store) appendPile(Height uint32, DataHash hash.Hash256, Datas [][]byte) error {
+	if st.cdb.Height() >= Height {
+		return nil
+	}
+	return st.cdb.AppendData(Height, DataHash, Datas)
+}
+
+// recoverCommit repairs the partial commit between the backend and the pile
+// the pile is rewritten from the batch journal when the batch is not completed
+// the pile can be behind the backend by one block and it is recovered from the journal
+// blocks over the backend height are remained in the pile and replayed by IterBlockAfterContext
+func (st *Store) recoverCommit() error {
+	var Height uint32
+	var journal *commitJournal
+	var batch []byte
+	if err := st.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(tagHeight)
+		if err != nil {
+			return err
+		}
+		Height = binutil.LittleEndian.Uint32(value)
+
+		if value, err := txn.Get(tagCommitBatch); err != nil {
+			if err != backend.ErrNotExistKey {
+				return err
+			}
+		} else {
+			batch = value
+			return nil
+		}
+
+		value, err = txn.Get(tagCommitJournal)
+		if err != nil {
+			if err != backend.ErrNotExistKey {
+				return err
+			}
+			return nil
+		}
+		var j commitJournal
+		if err := encoding.Unmarshal(value, &j); err != nil {
+			return err
+		}
+		journal = &j
+		return nil
+	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			return nil
+		}
+		return err
+	}
+	if Height <= st.cdb.InitHeight() {
+		return nil
+	}
+	if batch != nil {
+		return st.recoverBatch(Height, batch)
+	}
+	if journal != nil && journal.Height != Height {
+		return ErrInvalidCommitJournal
+	}
+
+	PileHeight := st.cdb.Height()
+	if PileHeight >= Height {
+		if journal != nil {
+			if err := st.checkPileHash(Height, journal.DataHash); err != nil {
+				return err
+			}
+		}
+		if PileHeight > Height {
+			log.Println("Store has blocks to replay from", Height+1, "to", PileHeight)
+		}
+		return nil
+	}
+	if PileHeight+1 != Height || journal == nil {
+		return ErrPileBehindState
+	}
+	if err := st.cdb.AppendData(journal.Height, journal.DataHash, journal.Datas); err != nil {
+		return err
+	}
+	log.Println("Store recovered the block", Height, "from the commit journal")
+	return nil
+}
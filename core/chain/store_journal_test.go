@@ -0,0 +1,222 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	_ "github.com/fletaio/fleta/core/backend/memory_driver"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+const crashExitCode = 3
+
+func openTestStore(t *testing.T, dir string) *Store {
+	return openTestStoreWith(t, "buntdb", dir)
+}
+
+// openTestStoreWith opens the test store with the driver
+// the memory driver is faster but it cannot be used in the crash test because it is not stored in files
+func openTestStoreWith(t *testing.T, Driver string, dir string) *Store {
+	back, err := backend.Create(Driver, filepath.Join(dir, "context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdb, err := pile.Open(filepath.Join(dir, "chain"), hash.Hash256{}, 0, 0)
+	if err != nil {
+		back.Close()
+		t.Fatal(err)
+	}
+	st, err := NewStore(back, cdb, 0xFF, "FLETA", "Test", 0x0001)
+	if err != nil {
+		back.Close()
+		cdb.Close()
+		t.Fatal(err)
+	}
+	return st
+}
+
+func storeTestBlock(st *Store, Height uint32) error {
+	b, ctd, err := newTestBlock(st, Height)
+	if err != nil {
+		return err
+	}
+	return st.StoreBlock(b, ctd)
+}
+
+func newTestBlock(st *Store, Height uint32) (*types.Block, *types.ContextData, error) {
+	PrevHash := st.LastHash()
+	LevelRootHash, err := BuildLevelRoot([]hash.Hash256{PrevHash})
+	if err != nil {
+		return nil, nil, err
+	}
+	b := &types.Block{
+		Header: types.Header{
+			ChainID:       0xFF,
+			Version:       0x0001,
+			Height:        Height,
+			PrevHash:      PrevHash,
+			LevelRootHash: LevelRootHash,
+			Timestamp:     uint64(Height),
+		},
+		TransactionTypes:      []uint16{},
+		Transactions:          []types.Transaction{},
+		TransactionSignatures: [][]common.Signature{},
+		Signatures:            []common.Signature{},
+	}
+	ctd := types.NewContextData(st, nil)
+	ctd.SetProcessData(1, []byte("height"), binutil.LittleEndian.Uint32ToBytes(Height))
+	return b, ctd, nil
+}
+
+func checkTestStore(t *testing.T, st *Store, Height uint32) {
+	if st.Height() != Height {
+		t.Fatal("invalid state height", st.Height(), Height)
+	}
+	if st.cdb.Height() != Height {
+		t.Fatal("invalid pile height", st.cdb.Height(), Height)
+	}
+	if v := st.ProcessData(1, []byte("height")); binutil.LittleEndian.Uint32(v) != Height {
+		t.Fatal("invalid process data", binutil.LittleEndian.Uint32(v), Height)
+	}
+	b, err := st.Block(Height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Header.Height != Height {
+		t.Fatal("invalid block height", b.Header.Height, Height)
+	}
+	if h, err := st.Hash(Height); err != nil {
+		t.Fatal(err)
+	} else if h != encoding.Hash(b.Header) {
+		t.Fatal("invalid block hash", Height)
+	}
+}
+
+// TestCrashHelper is executed in the child process by TestCrashRecovery
+func TestCrashHelper(t *testing.T) {
+	dir := os.Getenv("FLETA_CRASH_DIR")
+	if len(dir) == 0 {
+		t.Skip("executed by TestCrashRecovery")
+	}
+	step, err := strconv.Atoi(os.Getenv("FLETA_CRASH_STEP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := openTestStore(t, dir)
+	ctd := types.NewContextData(st, nil)
+	if err := st.StoreGenesis(hash.Hash256{}, ctd); err != nil {
+		t.Fatal(err)
+	}
+	for h := uint32(1); h <= 2; h++ {
+		if err := storeTestBlock(st, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(os.Getenv("FLETA_CRASH_BATCH")) > 0 {
+		for h := uint32(3); h <= 5; h++ {
+			b, ctd, err := newTestBlock(st, h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := st.PushBlock(b, ctd); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	commitHook = func(s int) {
+		if s == step {
+			os.Exit(crashExitCode)
+		}
+	}
+	if len(os.Getenv("FLETA_CRASH_BATCH")) > 0 {
+		st.FlushBlocks()
+	} else {
+		storeTestBlock(st, 3)
+	}
+	t.Fatal("not crashed at the step", step)
+}
+
+func TestCrashRecovery(t *testing.T) {
+	steps := []struct {
+		step   int
+		batch  bool
+		height uint32
+	}{
+		{stepBeforeState, false, 2},
+		{stepAfterState, false, 3},
+		{stepAfterPile, false, 3},
+		{stepBeforeState, true, 2},
+		{stepAfterState, true, 5},
+		{stepAfterPile, true, 5},
+	}
+	for _, v := range steps {
+		dir, err := ioutil.TempDir("", "fleta_store_")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		cmd := exec.Command(os.Args[0], "-test.run=^TestCrashHelper$")
+		cmd.Env = append(os.Environ(), "FLETA_CRASH_DIR="+dir, "FLETA_CRASH_STEP="+strconv.Itoa(v.step))
+		if v.batch {
+			cmd.Env = append(cmd.Env, "FLETA_CRASH_BATCH=1")
+		}
+		err = cmd.Run()
+		if e, is := err.(*exec.ExitError); !is || e.ExitCode() != crashExitCode {
+			t.Fatal("child is not crashed", v.step, err)
+		}
+
+		st := openTestStore(t, dir)
+		checkTestStore(t, st, v.height)
+		if err := storeTestBlock(st, v.height+1); err != nil {
+			t.Fatal(err)
+		}
+		checkTestStore(t, st, v.height+1)
+		st.Close()
+	}
+}
+
+func TestRecoverPileAhead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_store_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	st := openTestStore(t, dir)
+	if err := st.StoreGenesis(hash.Hash256{}, types.NewContextData(st, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := storeTestBlock(st, 1); err != nil {
+		t.Fatal(err)
+	}
+	// the pile is appended but the state is not committed as the previous store order
+	if err := st.cdb.AppendData(2, hash.Hash([]byte("forked")), [][]byte{}); err != nil {
+		t.Fatal(err)
+	}
+	st.Close()
+
+	st = openTestStore(t, dir)
+	defer st.Close()
+	if st.Height() != 1 || st.cdb.Height() != 2 {
+		t.Fatal("invalid recovered heights", st.Height(), st.cdb.Height())
+	}
+	if err := storeTestBlock(st, 2); err != ErrPileHashMismatch {
+		t.Fatal("conflicted block is stored", err)
+	}
+	if st.Height() != 1 {
+		t.Fatal("state is changed by the conflicted block", st.Height())
+	}
+}
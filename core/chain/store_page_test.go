@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func TestUTXOsPage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_store_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	st := openTestStoreWith(t, "memory", dir)
+	defer st.Close()
+	ctd := types.NewContextData(st, nil)
+	for id := uint64(1); id <= 5; id++ {
+		if err := ctd.CreateUTXO(id, types.NewTxOut()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.StoreGenesis(hash.Hash256{}, ctd); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, Reverse := range []bool{false, true} {
+		ids := []uint64{}
+		var Cursor []byte
+		pages := 0
+		for {
+			list, next, err := st.UTXOsPage(Cursor, 2, Reverse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, utxo := range list {
+				ids = append(ids, utxo.ID())
+			}
+			pages++
+			if next == nil {
+				break
+			}
+			Cursor = next
+		}
+		if pages != 3 || len(ids) != 5 {
+			t.Fatal("invalid pages", Reverse, pages, ids)
+		}
+		for i, id := range ids {
+			expected := uint64(i + 1)
+			if Reverse {
+				expected = uint64(5 - i)
+			}
+			if id != expected {
+				t.Fatal("invalid order", Reverse, ids)
+			}
+		}
+	}
+	if _, _, err := st.UTXOsPage(nil, 0, false); err != ErrInvalidLimit {
+		t.Fatal("invalid limit is not rejected", err)
+	}
+}
@@ -0,0 +1,100 @@
+package chain
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/pile"
+)
+
+// NewReadOnlyStore returns a Store which reads the backend and the pile of the writer process without writing
+// the commit journal is not recovered and the height follows the writer by Refresh
+func NewReadOnlyStore(db backend.StoreBackend, cdb *pile.DB, ChainID uint8, symbol string, usage string, version uint16) (*Store, error) {
+	st := &Store{
+		db:          db,
+		cdb:         cdb,
+		chainID:     ChainID,
+		symbol:      symbol,
+		usage:       usage,
+		version:     version,
+		timeSlotMap: map[uint32]map[string]bool{},
+		readOnly:    true,
+		stateCache:  newStateCache(DefaultCacheSize),
+	}
+	st.setupMagicNumber()
+	if _, err := st.Refresh(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// IsReadOnly returns whether the store is opened by NewReadOnlyStore
+func (st *Store) IsReadOnly() bool {
+	return st.readOnly
+}
+
+// Refresh loads updates of the writer process and returns the height of the store
+// the height is the lower one of the backend and the pile, so the block of the height is always readable
+// the state of the backend can include blocks over the height until the pile of the writer is synced
+func (st *Store) Refresh() (uint32, error) {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return 0, ErrStoreClosed
+	}
+	if !st.readOnly {
+		return st.Height(), nil
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	if rf, is := st.db.(backend.Refresher); is {
+		if err := rf.Refresh(); err != nil {
+			return 0, err
+		}
+	}
+	// the writer may have changed any key so the cached states are dropped
+	st.stateCache.purge()
+	Height, err := st.cdb.Refresh()
+	if err != nil {
+		return 0, err
+	}
+	if err := st.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(tagHeight)
+		if err != nil {
+			return err
+		}
+		if h := binutil.LittleEndian.Uint32(value); h < Height {
+			Height = h
+		}
+		return nil
+	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			// the writer has not stored the genesis yet
+			return 0, nil
+		}
+		return 0, err
+	}
+	if st.cache.cached && st.cache.height == Height {
+		return Height, nil
+	}
+
+	h, err := st.cdb.GetHash(Height)
+	if err != nil {
+		return 0, err
+	}
+	if Height > st.cdb.InitHeight() {
+		b, err := st.Block(Height)
+		if err != nil {
+			return 0, err
+		}
+		st.updateCache(b, h)
+	} else {
+		st.cache.height = Height
+		st.cache.heightHash = h
+		st.cache.heightBlock = nil
+		st.cache.heightTimestamp = st.cdb.InitTimestamp()
+		st.cache.cached = true
+	}
+	return Height, nil
+}
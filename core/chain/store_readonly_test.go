@@ -0,0 +1,168 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func openReadOnlyTestStore(t *testing.T, Driver string, dir string) *Store {
+	back, err := backend.CreateReadOnly(Driver, filepath.Join(dir, "context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdb, err := pile.OpenReadOnly(filepath.Join(dir, "chain"), hash.Hash256{}, 0, 0)
+	if err != nil {
+		back.Close()
+		t.Fatal(err)
+	}
+	st, err := NewReadOnlyStore(back, cdb, 0xFF, "FLETA", "Test", 0x0001)
+	if err != nil {
+		back.Close()
+		cdb.Close()
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestReadOnlyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_readonly_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	st := openTestStore(t, dir)
+	defer st.Close()
+	if err := st.StoreGenesis(hash.Hash256{}, types.NewContextData(st, nil)); err != nil {
+		t.Fatal(err)
+	}
+	for h := uint32(1); h <= 5; h++ {
+		if err := storeTestBlock(st, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rs := openReadOnlyTestStore(t, "buntdb", dir)
+	checkTestStore(t, rs, 5)
+	b, ctd, err := newTestBlock(rs, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.StoreBlock(b, ctd); err != ErrReadOnlyStore {
+		t.Fatal("read-only store should not store the block", err)
+	}
+
+	for h := uint32(6); h <= 10; h++ {
+		if err := storeTestBlock(st, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rs.Height() != 5 {
+		t.Fatal("height should be moved by Refresh", rs.Height())
+	}
+	if Height, err := rs.Refresh(); err != nil {
+		t.Fatal(err)
+	} else if Height != 10 {
+		t.Fatal("invalid refreshed height", Height)
+	}
+	checkTestStore(t, rs, 10)
+
+	// pending blocks of the writer are not visible until they are flushed
+	for h := uint32(11); h <= 13; h++ {
+		b, ctd, err := newTestBlock(st, h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := st.PushBlock(b, ctd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if Height, err := rs.Refresh(); err != nil {
+		t.Fatal(err)
+	} else if Height != 10 {
+		t.Fatal("pending blocks are visible", Height)
+	}
+	if err := st.FlushBlocks(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	checkTestStore(t, rs, 13)
+
+	rs.Close()
+	if err := storeTestBlock(st, 14); err != nil {
+		t.Fatal(err)
+	}
+	checkTestStore(t, st, 14)
+}
+
+type reloadTestConsensus struct {
+	ConsensusBase
+	count uint32
+}
+
+// OnLoadChain loads the counter of the batch test app as the memory state of the consensus
+func (cs *reloadTestConsensus) OnLoadChain(loader types.LoaderWrapper) error {
+	if bs := types.NewLoaderWrapper(255, loader).ProcessData([]byte("count")); len(bs) > 0 {
+		cs.count = binutil.LittleEndian.Uint32(bs)
+	}
+	return nil
+}
+
+func (cs *reloadTestConsensus) Init(cn *Chain, ct Committer) error {
+	return nil
+}
+
+func TestReadOnlyChainReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_readonly_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cs := &batchTestConsensus{}
+	cn := NewChain(cs, &batchTestApp{}, openTestStore(t, dir))
+	if err := cn.Init(hash.Hash256{}, hash.Hash256{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer cn.Close()
+	for h := uint32(1); h <= 5; h++ {
+		if _, err := generateBatchTestBlock(cs.ct); err != nil {
+			t.Fatal(h, err)
+		}
+	}
+
+	rcs := &reloadTestConsensus{}
+	rcn := NewChain(rcs, &batchTestApp{}, openReadOnlyTestStore(t, "buntdb", dir))
+	if err := rcn.Init(hash.Hash256{}, hash.Hash256{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer rcn.Close()
+	if rcs.count != 5 {
+		t.Fatal("invalid loaded count", rcs.count)
+	}
+
+	for h := uint32(6); h <= 10; h++ {
+		if _, err := generateBatchTestBlock(cs.ct); err != nil {
+			t.Fatal(h, err)
+		}
+	}
+	if _, err := rcn.store.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if err := rcn.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if rcs.count != 10 {
+		t.Fatal("memory state should be reloaded", rcs.count)
+	}
+}
@@ -20,6 +20,8 @@ var (
 	tagEvent               = []byte{5, 0}
 	tagLockedBalance       = []byte{6, 0}
 	tagLockedBalanceHeight = []byte{6, 1}
+	tagCommitJournal       = []byte{7, 0}
+	tagCommitBatch         = []byte{7, 1}
 )
 
 func toHeightBlockKey(height uint32) []byte {
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"bytes"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// StateHeight returns the height of the context stored in the backend
+func StateHeight(db backend.StoreBackend) (uint32, error) {
+	var height uint32
+	if err := db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(tagHeight)
+		if err != nil {
+			return err
+		}
+		height = binutil.LittleEndian.Uint32(value)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// LoadPileBlock returns the block of the height from the pile
+func LoadPileBlock(cdb *pile.DB, height uint32) (*types.Block, error) {
+	value, err := cdb.GetDatas(height, 0, 2)
+	if err != nil {
+		return nil, err
+	}
+	var b types.Block
+	if err := encoding.Unmarshal(value, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// CheckPileBlocks checks the hash chain and the consistency of the header and the body of blocks in the pile
+// it returns the last valid height and the error of the next height
+func CheckPileBlocks(cdb *pile.DB, ChainID uint8, From uint32, To uint32) (uint32, error) {
+	if From <= cdb.InitHeight() {
+		From = cdb.InitHeight() + 1
+	}
+	for h := From; h <= To; h++ {
+		if err := checkPileBlock(cdb, ChainID, h); err != nil {
+			return h - 1, err
+		}
+	}
+	return To, nil
+}
+
+func checkPileBlock(cdb *pile.DB, ChainID uint8, height uint32) error {
+	DataHash, err := cdb.GetHash(height)
+	if err != nil {
+		return err
+	}
+	PrevHash, err := cdb.GetHash(height - 1)
+	if err != nil {
+		return err
+	}
+	bsHeader, err := cdb.GetData(height, 0)
+	if err != nil {
+		return err
+	}
+	var bh types.Header
+	if err := encoding.Unmarshal(bsHeader, &bh); err != nil {
+		return err
+	}
+	b, err := LoadPileBlock(cdb, height)
+	if err != nil {
+		return err
+	}
+	if encoding.Hash(bh) != DataHash || encoding.Hash(b.Header) != DataHash {
+		return ErrInvalidDataHash
+	}
+	if b.Header.ChainID != ChainID {
+		return ErrInvalidChainID
+	}
+	if b.Header.Height != height {
+		return ErrInvalidHeight
+	}
+	if b.Header.PrevHash != PrevHash {
+		return ErrInvalidPrevHash
+	}
+	if len(b.TransactionTypes) != len(b.Transactions) || len(b.TransactionSignatures) != len(b.Transactions) {
+		return ErrInvalidHashCount
+	}
+	TxHashes := make([]hash.Hash256, 0, len(b.Transactions)+1)
+	TxHashes = append(TxHashes, b.Header.PrevHash)
+	for i, tx := range b.Transactions {
+		TxHashes = append(TxHashes, HashTransactionByType(ChainID, b.TransactionTypes[i], tx))
+	}
+	if h, err := BuildLevelRoot(TxHashes); err != nil {
+		return err
+	} else if b.Header.LevelRootHash != h {
+		return ErrInvalidLevelRootHash
+	}
+	return nil
+}
+
+// CompareBackends compares all keys and values of backends and returns the first different key
+// commit journals are not compared
+func CompareBackends(a backend.StoreBackend, b backend.StoreBackend) ([]byte, error) {
+	var diffKey []byte
+	compare := func(x backend.StoreBackend, y backend.StoreBackend) error {
+		return x.View(func(xtxn backend.StoreReader) error {
+			return y.View(func(ytxn backend.StoreReader) error {
+				return xtxn.Iterate([]byte{}, func(key []byte, value []byte) error {
+					// journals depend on the commit mode, not the state
+					if bytes.Equal(key, tagCommitJournal) || bytes.Equal(key, tagCommitBatch) {
+						return nil
+					}
+					v, err := ytxn.Get(key)
+					if err != nil && err != backend.ErrNotExistKey {
+						return err
+					}
+					if err == backend.ErrNotExistKey || !bytes.Equal(v, value) {
+						diffKey = append([]byte{}, key...)
+						return ErrStateMismatch
+					}
+					return nil
+				})
+			})
+		})
+	}
+	if err := compare(a, b); err != nil {
+		return diffKey, err
+	}
+	if err := compare(b, a); err != nil {
+		return diffKey, err
+	}
+	return nil, nil
+}
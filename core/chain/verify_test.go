@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func TestVerifyStore(t *testing.T) {
+	dirs := []string{}
+	stores := []*Store{}
+	for i := 0; i < 2; i++ {
+		dir, err := ioutil.TempDir("", "fleta_verify_")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		dirs = append(dirs, dir)
+
+		st := openTestStore(t, dir)
+		defer st.Close()
+		if err := st.StoreGenesis(hash.Hash256{}, types.NewContextData(st, nil)); err != nil {
+			t.Fatal(err)
+		}
+		for h := uint32(1); h <= 3; h++ {
+			if err := storeTestBlock(st, h); err != nil {
+				t.Fatal(err)
+			}
+		}
+		stores = append(stores, st)
+	}
+	st := stores[0]
+
+	statuses, err := pile.CheckDir(filepath.Join(dirs[0], "chain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || !statuses[0].IsConsistent() || statuses[0].HeadHeight != 3 {
+		t.Fatal("invalid pile status", statuses[0])
+	}
+	if h, err := CheckPileBlocks(st.cdb, 0xFF, 0, 3); err != nil || h != 3 {
+		t.Fatal("invalid pile blocks", h, err)
+	}
+	if h, err := CheckPileBlocks(st.cdb, 0x01, 0, 3); err != ErrInvalidChainID || h != 0 {
+		t.Fatal("invalid chain id is not detected", h, err)
+	}
+
+	if key, err := CompareBackends(stores[0].db, stores[1].db); err != nil {
+		t.Fatal("same states are different", key, err)
+	}
+	if err := stores[1].db.Update(func(txn backend.StoreWriter) error {
+		return txn.Set([]byte("diverged"), []byte{1})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := CompareBackends(stores[0].db, stores[1].db); err != ErrStateMismatch || string(key) != "diverged" {
+		t.Fatal("different states are same", key, err)
+	}
+
+	if err := st.cdb.Truncate(1); err != nil {
+		t.Fatal(err)
+	}
+	if st.cdb.Height() != 1 {
+		t.Fatal("pile is not truncated", st.cdb.Height())
+	}
+	if h, err := StateHeight(st.db); err != nil || h != 3 {
+		t.Fatal("invalid state height", h, err)
+	}
+}
@@ -0,0 +1,124 @@
+package pile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+)
+
+// codecs of the pile entry
+const (
+	CodecNone   = uint8(0)
+	CodecGzip   = uint8(1)
+	CodecSnappy = uint8(2)
+)
+
+// DefaultCodec is the codec of new entries
+const DefaultCodec = CodecSnappy
+
+// formats of the pile chunk
+// FormatGzip is the legacy format which compresses all entries by gzip without the codec
+// FormatCodec prefixes the codec to each entry
+const (
+	FormatGzip    = uint32(0)
+	FormatCodec   = uint32(1)
+	CurrentFormat = FormatCodec
+)
+
+var codecNames = map[uint8]string{
+	CodecNone:   "none",
+	CodecGzip:   "gzip",
+	CodecSnappy: "snappy",
+}
+
+// CodecName returns the name of the codec
+func CodecName(Codec uint8) string {
+	if name, has := codecNames[Codec]; has {
+		return name
+	}
+	return "unknown"
+}
+
+// CodecByName returns the codec of the name
+func CodecByName(name string) (uint8, error) {
+	for c, v := range codecNames {
+		if v == name {
+			return c, nil
+		}
+	}
+	return 0, ErrInvalidCodec
+}
+
+func gzipData(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	zw := gzip.NewWriter(&buffer)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func gunzipData(zd []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(zd))
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(zr)
+}
+
+// encodeEntry compresses the entry by the format of the chunk
+// the entry is stored without compression when it is not reduced by the codec
+func encodeEntry(Format uint32, Codec uint8, data []byte) ([]byte, error) {
+	if Format == FormatGzip {
+		return gzipData(data)
+	}
+	var zd []byte
+	switch Codec {
+	case CodecNone:
+	case CodecGzip:
+		v, err := gzipData(data)
+		if err != nil {
+			return nil, err
+		}
+		zd = v
+	case CodecSnappy:
+		zd = snappy.Encode(nil, data)
+	default:
+		return nil, ErrInvalidCodec
+	}
+	if zd == nil || len(zd) >= len(data) {
+		Codec = CodecNone
+		zd = data
+	}
+	bs := make([]byte, 1+len(zd))
+	bs[0] = Codec
+	copy(bs[1:], zd)
+	return bs, nil
+}
+
+// decodeEntry decompresses the entry by the format of the chunk
+func decodeEntry(Format uint32, zd []byte) ([]byte, error) {
+	if Format == FormatGzip {
+		return gunzipData(zd)
+	}
+	if len(zd) == 0 {
+		return nil, ErrInvalidCodec
+	}
+	switch zd[0] {
+	case CodecNone:
+		data := make([]byte, len(zd)-1)
+		copy(data, zd[1:])
+		return data, nil
+	case CodecGzip:
+		return gunzipData(zd[1:])
+	case CodecSnappy:
+		return snappy.Decode(nil, zd[1:])
+	default:
+		return nil, ErrInvalidCodec
+	}
+}
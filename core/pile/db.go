@@ -21,9 +21,11 @@ type DB struct {
 	initHeight    uint32
 	initTimestamp uint64
 	syncMode      bool
+	codec         uint8
 	hasDirty      bool
 	lastSyncTime  time.Time
 	isClosed      bool
+	readOnly      bool
 }
 
 // Open creates a DB that includes loaded piles
@@ -78,6 +80,7 @@ func Open(path string, initHash hash.Hash256, InitHeight uint32, InitTimestamp u
 		initHash:      initHash,
 		initHeight:    InitHeight,
 		initTimestamp: InitTimestamp,
+		codec:         DefaultCodec,
 	}
 	if len(piles) > 0 {
 		copy(db.genHash[:], db.piles[0].GenHash[:])
@@ -111,6 +114,9 @@ func (db *DB) Init(genHash hash.Hash256, initHash hash.Hash256, initHeight uint3
 	db.Lock()
 	defer db.Unlock()
 
+	if db.readOnly {
+		return ErrReadOnly
+	}
 	if len(db.piles) > 0 {
 		return ErrAlreadyInitialized
 	}
@@ -119,6 +125,7 @@ func (db *DB) Init(genHash hash.Hash256, initHash hash.Hash256, initHeight uint3
 	if err != nil {
 		return err
 	}
+	p.codec = db.codec
 	db.piles = append(db.piles, p)
 	db.genHash = genHash
 	db.initHash = initHash
@@ -151,6 +158,38 @@ func (db *DB) InitTimestamp() uint64 {
 	return db.initTimestamp
 }
 
+// Height returns the head height of the top pile
+func (db *DB) Height() uint32 {
+	db.Lock()
+	defer db.Unlock()
+
+	if len(db.piles) == 0 {
+		return db.initHeight
+	}
+	p := db.piles[len(db.piles)-1]
+	p.Lock()
+	defer p.Unlock()
+	return p.HeadHeight
+}
+
+// SetCodec changes the codec of new entries
+func (db *DB) SetCodec(Codec uint8) error {
+	if _, has := codecNames[Codec]; !has {
+		return ErrInvalidCodec
+	}
+
+	db.Lock()
+	defer db.Unlock()
+
+	db.codec = Codec
+	for _, p := range db.piles {
+		p.Lock()
+		p.codec = Codec
+		p.Unlock()
+	}
+	return nil
+}
+
 // SetSyncMode changes sync mode(sync every second when disabled)
 func (db *DB) SetSyncMode(sync bool) {
 	db.Lock()
@@ -164,6 +203,38 @@ func (db *DB) AppendData(Height uint32, DataHash hash.Hash256, Datas [][]byte) e
 	db.Lock()
 	defer db.Unlock()
 
+	return db.appendData(db.syncMode, Height, DataHash, Datas)
+}
+
+// AppendDataWithoutSync pushes data to top of the pile in piles without the sync
+// Sync should be called after appends to make them durable
+func (db *DB) AppendDataWithoutSync(Height uint32, DataHash hash.Hash256, Datas [][]byte) error {
+	db.Lock()
+	defer db.Unlock()
+
+	return db.appendData(false, Height, DataHash, Datas)
+}
+
+// Sync flushes appended data of the top pile to the disk
+func (db *DB) Sync() error {
+	db.Lock()
+	defer db.Unlock()
+
+	if len(db.piles) == 0 {
+		return nil
+	}
+	if err := db.piles[len(db.piles)-1].file.Sync(); err != nil {
+		return err
+	}
+	db.lastSyncTime = time.Now()
+	db.hasDirty = false
+	return nil
+}
+
+func (db *DB) appendData(sync bool, Height uint32, DataHash hash.Hash256, Datas [][]byte) error {
+	if db.readOnly {
+		return ErrReadOnly
+	}
 	if len(Datas) > 255 {
 		return ErrExeedMaximumDataArrayLength
 	}
@@ -180,11 +251,11 @@ func (db *DB) AppendData(Height uint32, DataHash hash.Hash256, Datas [][]byte) e
 		if err != nil {
 			return err
 		}
+		v.codec = db.codec
 		db.piles = append(db.piles, v)
 		p = v
 	}
 
-	sync := db.syncMode
 	now := time.Now()
 	if !sync {
 		if db.lastSyncTime.Sub(now) >= time.Second {
@@ -18,4 +18,9 @@ var (
 	ErrExeedMaximumDataArrayLength = errors.New("exceed maximum data array length")
 	ErrHeightCrashed               = errors.New("height crashed")
 	ErrUnderInitHeight             = errors.New("under init height")
+	ErrInvalidCodec                = errors.New("invalid codec")
+	ErrUnsupportedFormat           = errors.New("unsupported format")
+	ErrRecompressMismatch          = errors.New("recompress mismatch")
+	ErrClosedDB                    = errors.New("closed db")
+	ErrReadOnly                    = errors.New("read-only db")
 )
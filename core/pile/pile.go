@@ -2,8 +2,6 @@ package pile
 
 import (
 	"bytes"
-	"compress/gzip"
-	"io/ioutil"
 	"log"
 	"os"
 	"sync"
@@ -22,6 +20,8 @@ type Pile struct {
 	GenHash       hash.Hash256
 	InitHash      hash.Hash256
 	InitTimestamp uint64
+	Format        uint32
+	codec         uint8
 }
 
 // NewPile returns a Pile
@@ -62,6 +62,7 @@ func NewPile(path string, GenHash hash.Hash256, InitHash hash.Hash256, InitHeigh
 		copy(meta[52:], InitHash[:])                                              //InitialHash (52, 84)
 		copy(meta[84:], binutil.LittleEndian.Uint32ToBytes(InitHeight))           //BeginHeight (84, 88)
 		copy(meta[88:], binutil.LittleEndian.Uint64ToBytes(InitTimestamp))        //EndHeight (88, 96)
+		copy(meta[96:], binutil.LittleEndian.Uint32ToBytes(CurrentFormat))        //Format (96, 100)
 		if _, err := file.Write(meta); err != nil {
 			file.Close()
 			return nil, err
@@ -74,6 +75,8 @@ func NewPile(path string, GenHash hash.Hash256, InitHash hash.Hash256, InitHeigh
 		BeginHeight: BaseHeight,
 		GenHash:     GenHash,
 		InitHash:    InitHash,
+		Format:      CurrentFormat,
+		codec:       DefaultCodec,
 	}
 	return p, nil
 }
@@ -105,6 +108,11 @@ func LoadPile(path string) (*Pile, error) {
 	copy(InitHash[:], meta[52:])
 	InitHeight := binutil.LittleEndian.Uint32(meta[84:])
 	InitTimestamp := binutil.LittleEndian.Uint64(meta[88:])
+	Format := binutil.LittleEndian.Uint32(meta[96:])
+	if Format > CurrentFormat {
+		file.Close()
+		return nil, ErrUnsupportedFormat
+	}
 	if BeginHeight%ChunkUnit != 0 {
 		file.Close()
 		return nil, ErrInvalidChunkBeginHeight
@@ -189,6 +197,8 @@ func LoadPile(path string) (*Pile, error) {
 		InitHash:      InitHash,
 		InitHeight:    InitHeight,
 		InitTimestamp: InitTimestamp,
+		Format:        Format,
+		codec:         DefaultCodec,
 	}
 	return p, nil
 }
@@ -245,14 +255,10 @@ func (p *Pile) AppendData(Sync bool, Height uint32, DataHash hash.Hash256, Datas
 	}
 	zdatas := make([][]byte, 0, len(Datas))
 	for _, v := range Datas {
-		var buffer bytes.Buffer
-		zw := gzip.NewWriter(&buffer)
-		if _, err := zw.Write(v); err != nil {
+		zd, err := encodeEntry(p.Format, p.codec, v)
+		if err != nil {
 			return err
 		}
-		zw.Flush()
-		zw.Close()
-		zd := buffer.Bytes()
 		zdatas = append(zdatas, zd)
 
 		if _, err := p.file.Write(binutil.LittleEndian.Uint32ToBytes(uint32(len(zd)))); err != nil {
@@ -406,15 +412,7 @@ func (p *Pile) GetData(Height uint32, index int) ([]byte, error) {
 	if _, err := p.file.Read(zd); err != nil {
 		return nil, err
 	}
-	zr, err := gzip.NewReader(bytes.NewReader(zd))
-	if err != nil {
-		return nil, err
-	}
-	data, err := ioutil.ReadAll(zr)
-	if err != nil {
-		return nil, err
-	}
-	return data, nil
+	return decodeEntry(p.Format, zd)
 }
 
 // GetDatas returns datas of the height between from and from + count
@@ -472,11 +470,7 @@ func (p *Pile) GetDatas(Height uint32, from int, count int) ([]byte, error) {
 		if _, err := p.file.Read(zd); err != nil {
 			return nil, err
 		}
-		zr, err := gzip.NewReader(bytes.NewReader(zd))
-		if err != nil {
-			return nil, err
-		}
-		data, err := ioutil.ReadAll(zr)
+		data, err := decodeEntry(p.Format, zd)
 		if err != nil {
 			return nil, err
 		}
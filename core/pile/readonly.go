@@ -0,0 +1,153 @@
+package pile
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// OpenReadOnly opens piles which are written by another process without writing
+// the height follows the writer by Refresh
+func OpenReadOnly(path string, initHash hash.Hash256, InitHeight uint32, InitTimestamp uint64) (*DB, error) {
+	start := time.Now()
+	db := &DB{
+		path:          path,
+		piles:         []*Pile{},
+		initHash:      initHash,
+		initHeight:    InitHeight,
+		initTimestamp: InitTimestamp,
+		codec:         DefaultCodec,
+		readOnly:      true,
+	}
+	if _, err := db.refresh(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	log.Println("PileDB is opened as read-only in", time.Now().Sub(start))
+	return db, nil
+}
+
+// Refresh loads heights which are appended by the writer after the last refresh and returns the height
+// the height is moved when the writer has updated all head height checks of the pile
+// it just returns the height when the DB is not opened as read-only
+func (db *DB) Refresh() (uint32, error) {
+	db.Lock()
+	defer db.Unlock()
+
+	return db.refresh()
+}
+
+func (db *DB) refresh() (uint32, error) {
+	if db.isClosed {
+		return 0, ErrClosedDB
+	}
+	for {
+		if len(db.piles) > 0 {
+			p := db.piles[len(db.piles)-1]
+			if !db.readOnly {
+				return p.HeadHeight, nil
+			}
+			if err := p.refreshHead(); err != nil {
+				return 0, err
+			}
+			if p.HeadHeight-p.BeginHeight < ChunkUnit {
+				return p.HeadHeight, nil
+			}
+		} else if !db.readOnly {
+			return db.initHeight, nil
+		}
+		p, err := loadPileReadOnly(filepath.Join(db.path, "chain_"+strconv.Itoa(len(db.piles)+1)+".pile"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				if len(db.piles) == 0 {
+					return db.initHeight, nil
+				}
+				return db.piles[len(db.piles)-1].HeadHeight, nil
+			}
+			return 0, err
+		}
+		if len(db.piles) == 0 {
+			copy(db.genHash[:], p.GenHash[:])
+			copy(db.initHash[:], p.InitHash[:])
+			db.initTimestamp = p.InitTimestamp
+		}
+		db.piles = append(db.piles, p)
+	}
+}
+
+// loadPileReadOnly loads a pile from the file without the recovery of the head height
+func loadPileReadOnly(path string) (*Pile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make([]byte, ChunkMetaSize)
+	if _, err := file.ReadAt(meta, 0); err != nil {
+		file.Close()
+		return nil, err
+	}
+	BeginHeight := binutil.LittleEndian.Uint32(meta[12:])
+	EndHeight := binutil.LittleEndian.Uint32(meta[16:])
+	var GenHash hash.Hash256
+	copy(GenHash[:], meta[20:])
+	var InitHash hash.Hash256
+	copy(InitHash[:], meta[52:])
+	InitHeight := binutil.LittleEndian.Uint32(meta[84:])
+	InitTimestamp := binutil.LittleEndian.Uint64(meta[88:])
+	Format := binutil.LittleEndian.Uint32(meta[96:])
+	if Format > CurrentFormat {
+		file.Close()
+		return nil, ErrUnsupportedFormat
+	}
+	if BeginHeight%ChunkUnit != 0 {
+		file.Close()
+		return nil, ErrInvalidChunkBeginHeight
+	}
+	if BeginHeight+ChunkUnit != EndHeight {
+		file.Close()
+		return nil, ErrInvalidChunkEndHeight
+	}
+	p := &Pile{
+		file:          file,
+		HeadHeight:    committedHeight(meta),
+		BeginHeight:   BeginHeight,
+		GenHash:       GenHash,
+		InitHash:      InitHash,
+		InitHeight:    InitHeight,
+		InitTimestamp: InitTimestamp,
+		Format:        Format,
+		codec:         DefaultCodec,
+	}
+	return p, nil
+}
+
+// refreshHead reloads the head height of the pile which is written by another process
+func (p *Pile) refreshHead() error {
+	p.Lock()
+	defer p.Unlock()
+
+	bs := make([]byte, 12)
+	if _, err := p.file.ReadAt(bs, 0); err != nil {
+		return err
+	}
+	p.HeadHeight = committedHeight(bs)
+	return nil
+}
+
+// committedHeight returns the lowest one of the head height and its checks
+// entries of the height are written before the head height, so they are readable when all of them are updated
+func committedHeight(meta []byte) uint32 {
+	Height := binutil.LittleEndian.Uint32(meta)
+	for _, pos := range []int{4, 8} {
+		if v := binutil.LittleEndian.Uint32(meta[pos:]); v < Height {
+			Height = v
+		}
+	}
+	return Height
+}
@@ -0,0 +1,123 @@
+package pile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// RecompressResult is the result of the recompression of a pile file
+type RecompressResult struct {
+	Path    string
+	Count   uint32
+	OldSize int64
+	NewSize int64
+}
+
+// entries returns the hash and all entries of the height
+func (p *Pile) entries(Height uint32) (hash.Hash256, [][]byte, error) {
+	h, err := p.GetHash(Height)
+	if err != nil {
+		return hash.Hash256{}, nil, err
+	}
+	Datas := [][]byte{}
+	for i := 0; ; i++ {
+		data, err := p.GetData(Height, i)
+		if err != nil {
+			if err == ErrInvalidDataIndex {
+				break
+			}
+			return hash.Hash256{}, nil, err
+		}
+		Datas = append(Datas, data)
+	}
+	return h, Datas, nil
+}
+
+// Recompress rewrites all entries of the pile file by the codec with the current format
+// the file is replaced after the rewritten entries are verified and it should not be opened by the DB
+func Recompress(path string, Codec uint8) (*RecompressResult, error) {
+	if _, has := codecNames[Codec]; !has {
+		return nil, ErrInvalidCodec
+	}
+	src, err := LoadPile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	tmpPath := path + ".recompress"
+	os.Remove(tmpPath)
+	dst, err := NewPile(tmpPath, src.GenHash, src.InitHash, src.InitHeight, src.InitTimestamp, src.BeginHeight)
+	if err != nil {
+		return nil, err
+	}
+	isReplaced := false
+	defer func() {
+		dst.Close()
+		if !isReplaced {
+			os.Remove(tmpPath)
+		}
+	}()
+	dst.codec = Codec
+
+	From := dst.HeadHeight + 1
+	for h := From; h <= src.HeadHeight; h++ {
+		DataHash, Datas, err := src.entries(h)
+		if err != nil {
+			return nil, err
+		}
+		if err := dst.AppendData(false, h, DataHash, Datas); err != nil {
+			return nil, err
+		}
+	}
+	if err := dst.file.Sync(); err != nil {
+		return nil, err
+	}
+	for h := From; h <= src.HeadHeight; h++ {
+		SrcHash, SrcDatas, err := src.entries(h)
+		if err != nil {
+			return nil, err
+		}
+		DstHash, DstDatas, err := dst.entries(h)
+		if err != nil {
+			return nil, err
+		}
+		if SrcHash != DstHash || len(SrcDatas) != len(DstDatas) {
+			return nil, ErrRecompressMismatch
+		}
+		for i := range SrcDatas {
+			if !bytes.Equal(SrcDatas[i], DstDatas[i]) {
+				return nil, ErrRecompressMismatch
+			}
+		}
+	}
+
+	res := &RecompressResult{
+		Path: path,
+	}
+	if src.HeadHeight >= From {
+		res.Count = src.HeadHeight - From + 1
+	}
+	if fi, err := src.file.Stat(); err != nil {
+		return nil, err
+	} else {
+		res.OldSize = fi.Size()
+	}
+	if fi, err := dst.file.Stat(); err != nil {
+		return nil, err
+	} else {
+		res.NewSize = fi.Size()
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, err
+	}
+	isReplaced = true
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return res, nil
+}
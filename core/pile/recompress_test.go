@@ -0,0 +1,131 @@
+package pile
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+func testEntries(Height uint32) [][]byte {
+	return [][]byte{
+		bytes.Repeat(binutil.LittleEndian.Uint32ToBytes(Height), 64),
+		binutil.LittleEndian.Uint32ToBytes(Height),
+		[]byte{},
+	}
+}
+
+func checkTestPile(t *testing.T, p *Pile, Height uint32) {
+	for h := uint32(1); h <= Height; h++ {
+		DataHash, Datas, err := p.entries(h)
+		if err != nil {
+			t.Fatal(h, err)
+		}
+		if DataHash != hash.Hash(binutil.LittleEndian.Uint32ToBytes(h)) {
+			t.Fatal("invalid hash", h)
+		}
+		expected := testEntries(h)
+		if len(Datas) != len(expected) {
+			t.Fatal("invalid data count", h, len(Datas))
+		}
+		for i := range expected {
+			if !bytes.Equal(Datas[i], expected[i]) {
+				t.Fatal("invalid data", h, i)
+			}
+		}
+	}
+}
+
+func TestRecompress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fleta_pile_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the legacy pile has gzipped entries without the codec
+	path := filepath.Join(dir, "0.pile")
+	p, err := NewPile(path, hash.Hash256{}, hash.Hash256{}, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.file.WriteAt(binutil.LittleEndian.Uint32ToBytes(FormatGzip), 96); err != nil {
+		t.Fatal(err)
+	}
+	p.Format = FormatGzip
+	for h := uint32(1); h <= 10; h++ {
+		if err := p.AppendData(false, h, hash.Hash(binutil.LittleEndian.Uint32ToBytes(h)), testEntries(h)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.Close()
+
+	p, err = LoadPile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Format != FormatGzip {
+		t.Fatal("invalid format", p.Format)
+	}
+	checkTestPile(t, p, 10)
+	p.Close()
+
+	for _, Codec := range []uint8{CodecSnappy, CodecNone, CodecGzip} {
+		res, err := Recompress(path, Codec)
+		if err != nil {
+			t.Fatal(CodecName(Codec), err)
+		}
+		if res.Count != 10 {
+			t.Fatal("invalid count", res.Count)
+		}
+		p, err := LoadPile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Format != CurrentFormat || p.HeadHeight != 10 {
+			t.Fatal("invalid pile", p.Format, p.HeadHeight)
+		}
+		checkTestPile(t, p, 10)
+		p.Close()
+	}
+	if _, err := os.Stat(path + ".recompress"); !os.IsNotExist(err) {
+		t.Fatal("temporary file is remained")
+	}
+	if _, err := Recompress(path, 0xFF); err != ErrInvalidCodec {
+		t.Fatal("invalid codec is allowed", err)
+	}
+}
+
+func TestEntryCodec(t *testing.T) {
+	data := bytes.Repeat([]byte("fleta"), 100)
+	for _, Codec := range []uint8{CodecNone, CodecGzip, CodecSnappy} {
+		zd, err := encodeEntry(FormatCodec, Codec, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if zd[0] != Codec {
+			t.Fatal("invalid codec", zd[0], Codec)
+		}
+		if v, err := decodeEntry(FormatCodec, zd); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(v, data) {
+			t.Fatal("invalid data", CodecName(Codec))
+		}
+	}
+
+	// the incompressible entry is stored without the compression
+	zd, err := encodeEntry(FormatCodec, CodecSnappy, []byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zd[0] != CodecNone {
+		t.Fatal("small entry is compressed", zd[0])
+	}
+	if _, err := decodeEntry(FormatCodec, []byte{0xFF, 1}); err != ErrInvalidCodec {
+		t.Fatal("invalid codec is allowed", err)
+	}
+}
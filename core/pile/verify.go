@@ -0,0 +1,163 @@
+package pile
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fletaio/fleta/common/binutil"
+)
+
+// FileStatus is the integrity status of a pile file
+type FileStatus struct {
+	Path             string
+	BeginHeight      uint32
+	HeadHeight       uint32
+	HeadHeightCheckA uint32
+	HeadHeightCheckB uint32
+	Format           uint32
+	Size             int64
+	DataEnd          int64
+	Err              error
+}
+
+// IsConsistent returns true when the head heights and the data size are valid
+func (fs *FileStatus) IsConsistent() bool {
+	return fs.Err == nil && fs.HeadHeight == fs.HeadHeightCheckA && fs.HeadHeightCheckA == fs.HeadHeightCheckB
+}
+
+// CheckFile checks the meta of the pile file without repairing it
+func CheckFile(path string) (*FileStatus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	fs := &FileStatus{
+		Path: path,
+		Size: fi.Size(),
+	}
+	if fs.Size < ChunkHeaderSize {
+		fs.Err = ErrInvalidFileSize
+		return fs, nil
+	}
+	meta := make([]byte, ChunkMetaSize)
+	if _, err := file.ReadAt(meta, 0); err != nil {
+		return nil, err
+	}
+	fs.HeadHeight = binutil.LittleEndian.Uint32(meta)
+	fs.HeadHeightCheckA = binutil.LittleEndian.Uint32(meta[4:])
+	fs.HeadHeightCheckB = binutil.LittleEndian.Uint32(meta[8:])
+	fs.BeginHeight = binutil.LittleEndian.Uint32(meta[12:])
+	fs.Format = binutil.LittleEndian.Uint32(meta[96:])
+	EndHeight := binutil.LittleEndian.Uint32(meta[16:])
+	if fs.BeginHeight%ChunkUnit != 0 {
+		fs.Err = ErrInvalidChunkBeginHeight
+		return fs, nil
+	}
+	if fs.BeginHeight+ChunkUnit != EndHeight {
+		fs.Err = ErrInvalidChunkEndHeight
+		return fs, nil
+	}
+	if fs.HeadHeight < fs.BeginHeight || fs.HeadHeight > EndHeight {
+		fs.Err = ErrHeightCrashed
+		return fs, nil
+	}
+
+	fs.DataEnd = ChunkHeaderSize
+	if FromHeight := fs.HeadHeight - fs.BeginHeight; FromHeight > 0 {
+		bs := make([]byte, 8)
+		if _, err := file.ReadAt(bs, ChunkMetaSize+(int64(FromHeight)-1)*8); err != nil {
+			return nil, err
+		}
+		if Offset := int64(binutil.LittleEndian.Uint64(bs)); Offset > ChunkHeaderSize {
+			fs.DataEnd = Offset
+		}
+	}
+	if fs.Size < fs.DataEnd {
+		fs.Err = ErrInvalidFileSize
+	}
+	return fs, nil
+}
+
+// CheckDir checks all pile files in the path ordered by the begin height
+func CheckDir(path string) ([]*FileStatus, error) {
+	list := []*FileStatus{}
+	if err := filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() && filepath.Ext(p) == ".pile" {
+			fs, err := CheckFile(p)
+			if err != nil {
+				return err
+			}
+			list = append(list, fs)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].BeginHeight < list[j].BeginHeight
+	})
+	return list, nil
+}
+
+// Truncate removes data over the height
+func (db *DB) Truncate(Height uint32) error {
+	db.Lock()
+	defer db.Unlock()
+
+	if db.readOnly {
+		return ErrReadOnly
+	}
+	if Height < db.initHeight {
+		return ErrUnderInitHeight
+	}
+	for len(db.piles) > 1 {
+		p := db.piles[len(db.piles)-1]
+		if p.BeginHeight < Height {
+			break
+		}
+		path := p.file.Name()
+		p.Close()
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		db.piles = db.piles[:len(db.piles)-1]
+	}
+	if len(db.piles) == 0 {
+		return nil
+	}
+	return db.piles[len(db.piles)-1].truncate(Height)
+}
+
+// truncate moves the head height down with the same order of AppendData
+func (p *Pile) truncate(Height uint32) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if Height >= p.HeadHeight {
+		return nil
+	}
+	if Height < p.BeginHeight {
+		return ErrInvalidHeight
+	}
+	bs := binutil.LittleEndian.Uint32ToBytes(Height)
+	for _, pos := range []int64{0, 4, 8} {
+		if _, err := p.file.WriteAt(bs, pos); err != nil {
+			return err
+		}
+		if err := p.file.Sync(); err != nil {
+			return err
+		}
+	}
+	p.HeadHeight = Height
+	return nil
+}
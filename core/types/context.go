@@ -11,6 +11,7 @@ type Context struct {
 	genTargetHeight uint32
 	genLastHash     hash.Hash256
 	genTimestamp    uint64
+	keyResetHeight  uint32
 	cache           *contextCache
 	stack           []*ContextData
 	isLatestHash    bool
@@ -25,6 +26,9 @@ func NewContext(loader internalLoader) *Context {
 		genLastHash:     loader.LastHash(),
 		genTimestamp:    loader.LastTimestamp(),
 	}
+	if kl, is := loader.(keyResetHeightLoader); is {
+		ctx.keyResetHeight = kl.KeyResetHeight()
+	}
 	ctx.cache = newContextCache(ctx)
 	ctx.stack = []*ContextData{NewContextData(ctx.cache, nil)}
 	return ctx
@@ -60,6 +64,12 @@ func (ctx *Context) NextContext(NextHash hash.Hash256, Timestamp uint64) *Contex
 	return nctx
 }
 
+// Rebase replaces the loader of the Context
+// the loader should have the same state with the previous one (used when the previous contexts are stored)
+func (ctx *Context) Rebase(loader internalLoader) {
+	ctx.loader = loader
+}
+
 // Hash returns the hash value of it
 func (ctx *Context) Hash() hash.Hash256 {
 	if !ctx.isLatestHash {
@@ -69,6 +79,11 @@ func (ctx *Context) Hash() hash.Hash256 {
 	return ctx.dataHash
 }
 
+// KeyResetHeight returns the height from which a key set by a snapshot clears the deleted mark of the key
+func (ctx *Context) KeyResetHeight() uint32 {
+	return ctx.keyResetHeight
+}
+
 // TargetHeight returns the recorded target height when context generation
 func (ctx *Context) TargetHeight() uint32 {
 	return ctx.genTargetHeight
@@ -216,8 +231,10 @@ func (ctx *Context) Revert(sn int) {
 }
 
 // Commit apply snapshots to the top after the snapshot number
+// From the key reset height, a key that is set by a snapshot is not deleted anymore even if it is deleted before the snapshot
 func (ctx *Context) Commit(sn int) {
 	ctx.isLatestHash = false
+	isKeyReset := ctx.genTargetHeight >= ctx.keyResetHeight
 	for len(ctx.stack) >= sn {
 		ctd := ctx.Top()
 		ctx.stack = ctx.stack[:len(ctx.stack)-1]
@@ -236,6 +253,9 @@ func (ctx *Context) Commit(sn int) {
 			return true
 		})
 		ctd.AccountDataMap.EachAll(func(key string, value []byte) bool {
+			if isKeyReset {
+				top.DeletedAccountDataMap.Delete(key)
+			}
 			top.AccountDataMap.Put(key, value)
 			return true
 		})
@@ -263,6 +283,9 @@ func (ctx *Context) Commit(sn int) {
 		}
 		top.EventN = ctd.EventN
 		ctd.ProcessDataMap.EachAll(func(key string, value []byte) bool {
+			if isKeyReset {
+				top.DeletedProcessDataMap.Delete(key)
+			}
 			top.ProcessDataMap.Put(key, value)
 			return true
 		})
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+func TestContextCommitSetAfterDelete(t *testing.T) {
+	ctx := NewEmptyContext()
+	addr := common.NewAddress(0, 1, 0)
+	name := []byte("key")
+
+	sn := ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, []byte{1})
+	ctx.SetProcessData(1, name, []byte{1})
+	ctx.Commit(sn)
+
+	sn = ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, nil)
+	ctx.SetProcessData(1, name, nil)
+	ctx.Commit(sn)
+	if bs := ctx.AccountData(addr, 1, name); len(bs) > 0 {
+		t.Fatal("account data is not deleted", bs)
+	}
+	if bs := ctx.ProcessData(1, name); len(bs) > 0 {
+		t.Fatal("process data is not deleted", bs)
+	}
+
+	sn = ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, []byte{2})
+	ctx.SetProcessData(1, name, []byte{2})
+	ctx.Commit(sn)
+	if bs := ctx.AccountData(addr, 1, name); !bytes.Equal(bs, []byte{2}) {
+		t.Fatal("account data is not set again", bs)
+	}
+	if bs := ctx.ProcessData(1, name); !bytes.Equal(bs, []byte{2}) {
+		t.Fatal("process data is not set again", bs)
+	}
+	top := ctx.Top()
+	if top.DeletedAccountDataMap.Len() != 0 || top.DeletedProcessDataMap.Len() != 0 {
+		t.Fatal("deleted key remains with the value")
+	}
+}
+
+func TestContextCommitSetAfterDeleteBeforeKeyResetHeight(t *testing.T) {
+	ctx := NewEmptyContext()
+	ctx.keyResetHeight = ctx.TargetHeight() + 1
+	addr := common.NewAddress(0, 1, 0)
+	name := []byte("key")
+
+	sn := ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, []byte{1})
+	ctx.SetProcessData(1, name, []byte{1})
+	ctx.Commit(sn)
+
+	sn = ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, nil)
+	ctx.SetProcessData(1, name, nil)
+	ctx.Commit(sn)
+
+	sn = ctx.Snapshot()
+	ctx.SetAccountData(addr, 1, name, []byte{2})
+	ctx.SetProcessData(1, name, []byte{2})
+	ctx.Commit(sn)
+	top := ctx.Top()
+	if top.DeletedAccountDataMap.Len() != 1 || top.DeletedProcessDataMap.Len() != 1 {
+		t.Fatal("deleted mark is cleared before the key reset height")
+	}
+	if nctx := ctx.NextContext(hash.Hash256{}, 0); nctx.KeyResetHeight() != ctx.KeyResetHeight() {
+		t.Fatal("key reset height is not inherited", nctx.KeyResetHeight())
+	}
+}
@@ -28,6 +28,11 @@ type internalLoader interface {
 	ProcessData(pid uint8, name []byte) []byte
 }
 
+// keyResetHeightLoader is implemented by the loader that has the activation height of the key reset of the snapshot commit
+type keyResetHeightLoader interface {
+	KeyResetHeight() uint32
+}
+
 type emptyLoader struct {
 }
 
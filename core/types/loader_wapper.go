@@ -5,6 +5,7 @@ import "github.com/fletaio/fleta/common"
 // LoaderWrapper is an interface to load state data from the target chain
 type LoaderWrapper interface {
 	Loader
+	LastTimestamp() uint64
 	AccountData(addr common.Address, name []byte) []byte
 	ProcessData(name []byte) []byte
 }
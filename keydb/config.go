@@ -4,6 +4,8 @@
 // a dependable database, and favor speed over data size.
 package keydb
 
+import "strings"
+
 // SyncPolicy represents how often data is synced to disk.
 type SyncPolicy int
 
@@ -30,3 +32,17 @@ type Config struct {
 	// AutoShrinkDisabled turns off automatic background shrinking
 	AutoShrinkDisabled bool
 }
+
+// ParseSyncPolicy returns the SyncPolicy of the name (never, everysecond or always)
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	switch strings.ToLower(name) {
+	case "never":
+		return Never, nil
+	case "everysecond", "":
+		return EverySecond, nil
+	case "always":
+		return Always, nil
+	default:
+		return Never, ErrInvalidSyncPolicy
+	}
+}
@@ -46,7 +46,7 @@ type DB struct {
 // Open opens a database at the provided path.
 // If the file does not exist then it will be created automatically.
 func Open(path string, fn Unmarshaler) (*DB, error) {
-	os.MkdirAll(filepath.Dir(path), os.ModeDir)
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
 
 	if fn == nil {
 		fn = defaultUnmarshal
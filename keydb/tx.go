@@ -153,10 +153,9 @@ func (tx *Tx) Commit() error {
 		// Flushing the buffer only once per transaction.
 		// If this operation fails then the write did failed and we must
 		// rollback.
-		if _, err := tx.db.file.Seek(0, 2); err != nil {
+		if _, err = tx.db.file.Seek(0, 2); err != nil {
 			tx.rollbackInner()
-		}
-		if _, err := tx.db.file.Write(tx.db.buf); err != nil {
+		} else if _, err = tx.db.file.Write(tx.db.buf); err != nil {
 			tx.rollbackInner()
 		}
 		if tx.db.config.SyncPolicy == Always {
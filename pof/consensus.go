@@ -1,10 +1,10 @@
 package pof
 
 import (
-	"bytes"
 	"sync"
 
 	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/clock"
 	"github.com/fletaio/fleta/core/chain"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/encoding"
@@ -17,31 +17,59 @@ type Consensus struct {
 	*chain.ConsensusBase
 	cn                     *chain.Chain
 	ct                     chain.Committer
+	config                 *ConsensusConfig
+	clock                  clock.Clock
 	maxBlocksPerFormulator uint32
 	blocksBySameFormulator uint32
 	observerKeyMap         *types.PublicHashBoolMap
+	observerKeyHistory     []*observerKeySet
+	keyChangers            []ObserverKeyChanger
 	rt                     *RankTable
 }
 
 // NewConsensus returns a Consensus
-func NewConsensus(MaxBlocksPerFormulator uint32, ObserverKeys []common.PublicHash) *Consensus {
+func NewConsensus(Config *ConsensusConfig, ObserverKeys []common.PublicHash) *Consensus {
+	if Config == nil {
+		Config = DefaultConsensusConfig()
+	}
+	Config.fillDefault()
 	ObserverKeyMap := types.NewPublicHashBoolMap()
 	for _, pubhash := range ObserverKeys {
 		ObserverKeyMap.Put(pubhash.Clone(), true)
 	}
 	cs := &Consensus{
-		maxBlocksPerFormulator: MaxBlocksPerFormulator,
+		config:                 Config,
+		clock:                  clock.System,
+		maxBlocksPerFormulator: Config.MaxBlocksPerFormulator,
 		observerKeyMap:         ObserverKeyMap,
+		observerKeyHistory:     []*observerKeySet{&observerKeySet{Height: 0, KeyMap: ObserverKeyMap}},
 		rt:                     NewRankTable(),
 	}
 	return cs
 }
 
+// Config returns the config of the consensus
+func (cs *Consensus) Config() *ConsensusConfig {
+	return cs.config
+}
+
+// SetClock sets the clock that is used by nodes of the consensus
+// it should be called before creating nodes
+func (cs *Consensus) SetClock(c clock.Clock) {
+	cs.clock = c
+}
+
 // Init initializes the consensus
 func (cs *Consensus) Init(cn *chain.Chain, ct chain.Committer) error {
 	cs.cn = cn
 	cs.ct = ct
 
+	for _, p := range cn.Processes() {
+		if kc, is := p.(ObserverKeyChanger); is {
+			cs.keyChangers = append(cs.keyChangers, kc)
+		}
+	}
+
 	if vs, err := cn.ServiceByName("fleta.apiserver"); err != nil {
 		//ignore when not loaded
 	} else if v, is := vs.(*apiserver.APIServer); !is {
@@ -55,6 +83,12 @@ func (cs *Consensus) Init(cn *chain.Chain, ct chain.Committer) error {
 			list := cs.rt.Candidates()
 			return list, nil
 		})
+		js.Set("getObserverKeys", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return cs.ObserverKeyMap(), nil
+		})
+		js.Set("getConfig", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return cs.config, nil
+		})
 	}
 	return nil
 }
@@ -80,41 +114,17 @@ func (cs *Consensus) OnLoadChain(loader types.LoaderWrapper) error {
 	cs.Lock()
 	defer cs.Unlock()
 
-	dec := encoding.NewDecoder(bytes.NewReader(loader.ProcessData(tagState)))
-	if v, err := dec.DecodeUint32(); err != nil {
-		return err
-	} else {
-		if cs.maxBlocksPerFormulator != v {
-			return ErrInvalidMaxBlocksPerFormulator
-		}
-	}
-	ObserverKeyMap := types.NewPublicHashBoolMap()
-	if err := dec.Decode(&ObserverKeyMap); err != nil {
-		return err
-	} else {
-		if ObserverKeyMap.Len() != cs.observerKeyMap.Len() {
-			return ErrInvalidObserverKey
-		}
-		var inErr error
-		ObserverKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
-			if !cs.observerKeyMap.Has(pubhash) {
-				inErr = ErrInvalidObserverKey
-				return false
-			}
-			return true
-		})
-		if inErr != nil {
-			return inErr
-		}
-	}
-	if v, err := dec.DecodeUint32(); err != nil {
+	st, err := decodeSaveData(loader.ProcessData(tagState))
+	if err != nil {
 		return err
-	} else {
-		cs.blocksBySameFormulator = v
 	}
-	if err := dec.Decode(&cs.rt); err != nil {
-		return err
+	if cs.maxBlocksPerFormulator != st.maxBlocksPerFormulator {
+		return ErrInvalidMaxBlocksPerFormulator
 	}
+	cs.observerKeyMap = st.observerKeyMap
+	cs.blocksBySameFormulator = st.blocksBySameFormulator
+	cs.rt = st.rt
+	cs.observerKeyHistory = st.observerKeyHistory
 	return nil
 }
 
@@ -143,11 +153,12 @@ func (cs *Consensus) ValidateSignature(bh *types.Header, sigs []common.Signature
 		return ErrInvalidTopSignature
 	}
 
-	if len(sigs) != cs.observerKeyMap.Len()/2+2 {
+	ObserverKeyMap := cs.observerKeyMapAt(bh.Height)
+	if len(sigs) != ObserverKeyMap.Len()/2+2 {
 		return ErrInvalidSignatureCount
 	}
 	KeyMap := map[common.PublicHash]bool{}
-	cs.observerKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
+	ObserverKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
 		KeyMap[pubhash] = true
 		return true
 	})
@@ -188,6 +199,10 @@ func (cs *Consensus) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error
 	if err := cs.updateFormulatorList(ctw); err != nil {
 		return err
 	}
+	if err := cs.applyObserverKeyChanges(ctw, b.Header.Height+1); err != nil {
+		return err
+	}
+	cs.pruneObserverKeyHistory(b.Header.Height + 1)
 	if data, err := cs.buildSaveData(); err != nil {
 		return err
 	} else {
@@ -0,0 +1,71 @@
+package pof
+
+import (
+	"time"
+)
+
+// ConsensusConfig defines the limits and the timeouts of the consensus
+// times are in milliseconds and zero values are replaced by the default values
+// MaxBlocksPerFormulator is stored in the consensus state because it affects the validity of blocks
+type ConsensusConfig struct {
+	MaxBlocksPerFormulator uint32
+	BlockTime              uint32
+	RoundVoteTimeout       uint32
+	BlockGenTimeout        uint32
+	BlockGenRequestTimeout uint32
+	RequestTimeout         uint32
+}
+
+// DefaultConsensusConfig returns the default ConsensusConfig
+func DefaultConsensusConfig() *ConsensusConfig {
+	return &ConsensusConfig{
+		MaxBlocksPerFormulator: 10,
+		BlockTime:              uint32(BlockTime / time.Millisecond),
+		RoundVoteTimeout:       2000,
+		BlockGenTimeout:        30000,
+		BlockGenRequestTimeout: 1000,
+		RequestTimeout:         2000,
+	}
+}
+
+func (cfg *ConsensusConfig) fillDefault() {
+	def := DefaultConsensusConfig()
+	if cfg.MaxBlocksPerFormulator == 0 {
+		cfg.MaxBlocksPerFormulator = def.MaxBlocksPerFormulator
+	}
+	if cfg.BlockTime == 0 {
+		cfg.BlockTime = def.BlockTime
+	}
+	if cfg.RoundVoteTimeout == 0 {
+		cfg.RoundVoteTimeout = def.RoundVoteTimeout
+	}
+	if cfg.BlockGenTimeout == 0 {
+		cfg.BlockGenTimeout = def.BlockGenTimeout
+	}
+	if cfg.BlockGenRequestTimeout == 0 {
+		cfg.BlockGenRequestTimeout = def.BlockGenRequestTimeout
+	}
+	if cfg.RequestTimeout == 0 {
+		cfg.RequestTimeout = def.RequestTimeout
+	}
+}
+
+func (cfg *ConsensusConfig) blockTime() time.Duration {
+	return time.Duration(cfg.BlockTime) * time.Millisecond
+}
+
+func (cfg *ConsensusConfig) roundVoteTimeout() time.Duration {
+	return time.Duration(cfg.RoundVoteTimeout) * time.Millisecond
+}
+
+func (cfg *ConsensusConfig) blockGenTimeout() time.Duration {
+	return time.Duration(cfg.BlockGenTimeout) * time.Millisecond
+}
+
+func (cfg *ConsensusConfig) blockGenRequestTimeout() time.Duration {
+	return time.Duration(cfg.BlockGenRequestTimeout) * time.Millisecond
+}
+
+func (cfg *ConsensusConfig) requestTimeout() time.Duration {
+	return time.Duration(cfg.RequestTimeout) * time.Millisecond
+}
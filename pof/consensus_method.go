@@ -2,6 +2,7 @@ package pof
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/hash"
@@ -104,9 +105,200 @@ func (cs *Consensus) buildSaveData() ([]byte, error) {
 	if err := enc.Encode(cs.rt); err != nil {
 		return nil, err
 	}
+	if len(cs.observerKeyHistory) > 1 || cs.observerKeyHistory[0].Height > 0 {
+		if err := enc.EncodeArrayLen(len(cs.observerKeyHistory)); err != nil {
+			return nil, err
+		}
+		for _, ks := range cs.observerKeyHistory {
+			if err := enc.EncodeUint32(ks.Height); err != nil {
+				return nil, err
+			}
+			if err := enc.Encode(ks.KeyMap); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
+// saveData is the state of the consensus that is saved to the process data
+type saveData struct {
+	maxBlocksPerFormulator uint32
+	observerKeyMap         *types.PublicHashBoolMap
+	blocksBySameFormulator uint32
+	rt                     *RankTable
+	observerKeyHistory     []*observerKeySet
+}
+
+func decodeSaveData(data []byte) (*saveData, error) {
+	st := &saveData{
+		observerKeyMap: types.NewPublicHashBoolMap(),
+		rt:             NewRankTable(),
+	}
+	dec := encoding.NewDecoder(bytes.NewReader(data))
+	if v, err := dec.DecodeUint32(); err != nil {
+		return nil, err
+	} else {
+		st.maxBlocksPerFormulator = v
+	}
+	if err := dec.Decode(&st.observerKeyMap); err != nil {
+		return nil, err
+	}
+	if v, err := dec.DecodeUint32(); err != nil {
+		return nil, err
+	} else {
+		st.blocksBySameFormulator = v
+	}
+	if err := dec.Decode(&st.rt); err != nil {
+		return nil, err
+	}
+	if history, err := decodeObserverKeyHistory(dec); err != nil {
+		if err != io.EOF {
+			return nil, err
+		}
+		st.observerKeyHistory = []*observerKeySet{&observerKeySet{Height: 0, KeyMap: st.observerKeyMap}}
+	} else {
+		st.observerKeyHistory = history
+	}
+	return st, nil
+}
+
+func decodeObserverKeyHistory(dec *encoding.Decoder) ([]*observerKeySet, error) {
+	Len, err := dec.DecodeArrayLen()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*observerKeySet, 0, Len)
+	for i := 0; i < Len; i++ {
+		Height, err := dec.DecodeUint32()
+		if err != nil {
+			return nil, err
+		}
+		KeyMap := types.NewPublicHashBoolMap()
+		if err := dec.Decode(&KeyMap); err != nil {
+			return nil, err
+		}
+		history = append(history, &observerKeySet{Height: Height, KeyMap: KeyMap})
+	}
+	return history, nil
+}
+
+// ObserverKeyMap returns the observer key map of the next block
+func (cs *Consensus) ObserverKeyMap() *types.PublicHashBoolMap {
+	cs.Lock()
+	defer cs.Unlock()
+
+	return cs.observerKeyMap
+}
+
+func (cs *Consensus) observerKeyMapAt(Height uint32) *types.PublicHashBoolMap {
+	cs.Lock()
+	defer cs.Unlock()
+
+	for i := len(cs.observerKeyHistory) - 1; i >= 0; i-- {
+		ks := cs.observerKeyHistory[i]
+		if ks.Height <= Height {
+			return ks.KeyMap
+		}
+	}
+	return cs.observerKeyMap
+}
+
+// IsObserverKeyAt returns the public hash was an observer key at the height or not
+// it reads the state that is saved to the loader so the result only depends on the chain data
+// the key history of the state is pruned to the ObserverKeyHistoryDepth when the block is saved
+func (cs *Consensus) IsObserverKeyAt(loader types.Loader, Height uint32, pubhash common.PublicHash) (bool, error) {
+	st, err := decodeSaveData(types.NewLoaderWrapper(0, loader).ProcessData(tagState))
+	if err != nil {
+		return false, err
+	}
+	for i := len(st.observerKeyHistory) - 1; i >= 0; i-- {
+		ks := st.observerKeyHistory[i]
+		if ks.Height <= Height {
+			return ks.KeyMap.Has(pubhash), nil
+		}
+	}
+	return false, ErrPrunedObserverKeyHistory
+}
+
+// ObserverKeysAt returns observer keys at the height from the state that is saved to the loader
+func (cs *Consensus) ObserverKeysAt(loader types.Loader, Height uint32) ([]common.PublicHash, error) {
+	st, err := decodeSaveData(types.NewLoaderWrapper(0, loader).ProcessData(tagState))
+	if err != nil {
+		return nil, err
+	}
+	for i := len(st.observerKeyHistory) - 1; i >= 0; i-- {
+		ks := st.observerKeyHistory[i]
+		if ks.Height <= Height {
+			keys := make([]common.PublicHash, 0, ks.KeyMap.Len())
+			ks.KeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
+				keys = append(keys, pubhash)
+				return true
+			})
+			return keys, nil
+		}
+	}
+	return nil, ErrPrunedObserverKeyHistory
+}
+
+// IsFormulator returns the formulator is in the rank table of the state that is saved to the loader with the public hash or not
+func (cs *Consensus) IsFormulator(loader types.Loader, Formulator common.Address, Publichash common.PublicHash) (bool, error) {
+	st, err := decodeSaveData(types.NewLoaderWrapper(0, loader).ProcessData(tagState))
+	if err != nil {
+		return false, err
+	}
+	return st.rt.IsFormulator(Formulator, Publichash), nil
+}
+
+func (cs *Consensus) applyObserverKeyChanges(ctw *types.ContextWrapper, Height uint32) error {
+	KeyMap := types.NewPublicHashBoolMap()
+	cs.observerKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
+		KeyMap.Put(pubhash, value)
+		return true
+	})
+	changed := false
+	for _, kc := range cs.keyChangers {
+		AddKeys, RemoveKeys, err := kc.ObserverKeyChanges(ctw, Height)
+		if err != nil {
+			return err
+		}
+		for _, pubhash := range RemoveKeys {
+			if KeyMap.Has(pubhash) {
+				KeyMap.Delete(pubhash)
+				changed = true
+			}
+		}
+		for _, pubhash := range AddKeys {
+			if !KeyMap.Has(pubhash) {
+				KeyMap.Put(pubhash.Clone(), true)
+				changed = true
+			}
+		}
+	}
+	// an empty observer set cannot sign any block so the change is ignored
+	if !changed || KeyMap.Len() == 0 {
+		return nil
+	}
+	cs.observerKeyMap = KeyMap
+	cs.observerKeyHistory = append(cs.observerKeyHistory, &observerKeySet{Height: Height, KeyMap: KeyMap})
+	return nil
+}
+
+// pruneObserverKeyHistory removes key sets that are replaced before the history depth but keeps the set in force at that height
+func (cs *Consensus) pruneObserverKeyHistory(Height uint32) {
+	if Height <= ObserverKeyHistoryDepth {
+		return
+	}
+	BaseHeight := Height - ObserverKeyHistoryDepth
+	i := 0
+	for i+1 < len(cs.observerKeyHistory) && cs.observerKeyHistory[i+1].Height <= BaseHeight {
+		i++
+	}
+	if i > 0 {
+		cs.observerKeyHistory = append([]*observerKeySet{}, cs.observerKeyHistory[i:]...)
+	}
+}
+
 func (cs *Consensus) RankTable() *RankTable {
 	return cs.rt
 }
@@ -20,4 +20,5 @@ var (
 	ErrAlreadyVoted                  = errors.New("already voted")
 	ErrNotExistObserverPeer          = errors.New("not exist observer peer")
 	ErrNotExistFormulatorPeer        = errors.New("not exist formulator peer")
+	ErrPrunedObserverKeyHistory      = errors.New("pruned observer key history")
 )
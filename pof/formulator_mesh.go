@@ -40,6 +40,9 @@ func NewFormulatorNodeMesh(key key.Key, NetAddressMap map[common.PublicHash]stri
 func (ms *FormulatorNodeMesh) Run() {
 	myPubHash := common.NewPublicHash(ms.key.PublicKey())
 	for PubHash, v := range ms.netAddressMap {
+		if len(v) == 0 {
+			continue
+		}
 		go func(pubhash common.PublicHash, NetAddr string) {
 			time.Sleep(1 * time.Second)
 			for {
@@ -59,6 +62,23 @@ func (ms *FormulatorNodeMesh) Run() {
 	}
 }
 
+// AddPeer adds a peer which is connected outside of the mesh and handles it until it is closed
+func (ms *FormulatorNodeMesh) AddPeer(p peer.Peer) {
+	ID := p.ID()
+	ms.RemovePeer(ID)
+	ms.Lock()
+	ms.peerMap[ID] = p
+	ms.Unlock()
+
+	go func() {
+		defer ms.RemovePeer(p.ID())
+
+		if err := ms.handleConnection(p); err != nil {
+			rlog.Println("[handleConnection]", err)
+		}
+	}()
+}
+
 // Peers returns peers of the formulator mesh
 func (ms *FormulatorNodeMesh) Peers() []peer.Peer {
 	ms.Lock()
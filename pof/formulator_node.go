@@ -86,7 +86,7 @@ func NewFormulatorNode(Config *FormulatorConfig, key key.Key, ndkey key.Key, Net
 		lastGenItemMap: map[uint32]*genItem{},
 		statusMap:      map[string]*p2p.Status{},
 		obStatusMap:    map[string]*p2p.Status{},
-		requestTimer:   p2p.NewRequestTimer(nil),
+		requestTimer:   p2p.NewRequestTimerWithClock(nil, cs.clock),
 		blockQ:         queue.NewSortedQueue(),
 		txpool:         txpool.NewTransactionPool(),
 		txQ:            queue.NewExpireQueue(),
@@ -214,7 +214,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 						break
 					}
 				}
-				time.Sleep(100 * time.Millisecond)
+				fr.cs.clock.Sleep(100 * time.Millisecond)
 			}
 		}()
 	}
@@ -254,7 +254,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 					fr.broadcastMessage(1, msg)
 				}
 			}
-			time.Sleep(100 * time.Millisecond)
+			fr.cs.clock.Sleep(100 * time.Millisecond)
 		}
 	}()
 
@@ -303,7 +303,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 		for !fr.isClose {
 			fr.tryRequestBlocks()
 			fr.tryRequestNext()
-			time.Sleep(500 * time.Millisecond)
+			fr.cs.clock.Sleep(500 * time.Millisecond)
 		}
 	}()
 
@@ -388,9 +388,9 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 
 		if Count < 10 {
 			if hasItem {
-				time.Sleep(50 * time.Millisecond)
+				fr.cs.clock.Sleep(50 * time.Millisecond)
 			} else {
-				time.Sleep(200 * time.Millisecond)
+				fr.cs.clock.Sleep(200 * time.Millisecond)
 			}
 		}
 	}
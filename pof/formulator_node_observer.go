@@ -65,7 +65,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 			return nil
 		}
 		if msg.TargetHeight <= fr.lastGenHeight {
-			if time.Now().UnixNano() < fr.lastGenTime+int64(30*time.Second) {
+			if fr.cs.clock.Now().UnixNano() < fr.lastGenTime+int64(fr.cs.config.blockGenTimeout()) {
 				return nil
 			}
 			fr.lastReqLock.Lock()
@@ -104,7 +104,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 				p.SendPacket(p2p.MessageToPacket(sm))
 			}
 			go func() {
-				time.Sleep(50 * time.Millisecond)
+				fr.cs.clock.Sleep(50 * time.Millisecond)
 				fr.handleObserverMessage(p, m, RetryCount+1)
 			}()
 			return nil
@@ -440,10 +440,10 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		RemainBlocks = fr.cs.maxBlocksPerFormulator - fr.cs.blocksBySameFormulator
 	}
 
-	start := time.Now().UnixNano()
-	Now := uint64(time.Now().UnixNano())
+	start := fr.cs.clock.Now().UnixNano()
+	Now := uint64(fr.cs.clock.Now().UnixNano())
 	StartBlockTime := Now
-	EndBlockTime := StartBlockTime + uint64(BlockTime)*uint64(RemainBlocks)
+	EndBlockTime := StartBlockTime + uint64(fr.cs.config.blockTime())*uint64(RemainBlocks)
 
 	LastTimestamp := cp.LastTimestamp()
 	if StartBlockTime < LastTimestamp {
@@ -463,7 +463,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			ctx = ctx.NextContext(encoding.Hash(lastHeader), lastHeader.Timestamp)
 		}
 
-		Timestamp := StartBlockTime + uint64(i)*uint64(BlockTime)
+		Timestamp := StartBlockTime + uint64(i)*uint64(fr.cs.config.blockTime())
 		if Timestamp > EndBlockTime {
 			Timestamp = EndBlockTime
 		}
@@ -481,7 +481,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			return err
 		}
 
-		timer := time.NewTimer(200 * time.Millisecond)
+		timer := fr.cs.clock.NewTimer(200 * time.Millisecond)
 
 		fr.txpool.Lock() // Prevent delaying from TxPool.Push
 		Count := 0
@@ -489,7 +489,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 	TxLoop:
 		for {
 			select {
-			case <-timer.C:
+			case <-timer.C():
 				break TxLoop
 			default:
 				sn := ctx.Snapshot()
@@ -534,15 +534,15 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			Context:  ctx,
 		}
 		fr.lastGenHeight = ctx.TargetHeight()
-		fr.lastGenTime = time.Now().UnixNano()
+		fr.lastGenTime = fr.cs.clock.Now().UnixNano()
 
-		ExpectedTime := 200*time.Millisecond + time.Duration(i)*BlockTime
+		ExpectedTime := 200*time.Millisecond + time.Duration(i)*fr.cs.config.blockTime()
 		if i == 0 {
 			ExpectedTime = 200 * time.Millisecond
 		} else if i >= 9 {
-			ExpectedTime = BlockTime*time.Duration(i-1) + 400*time.Millisecond
+			ExpectedTime = fr.cs.config.blockTime()*time.Duration(i-1) + 400*time.Millisecond
 		}
-		PastTime := time.Duration(time.Now().UnixNano() - start)
+		PastTime := time.Duration(fr.cs.clock.Now().UnixNano() - start)
 		if ExpectedTime > PastTime {
 			IsEnd := false
 			fr.Unlock()
@@ -552,7 +552,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 				IsEnd = true
 			}
 			if !IsEnd {
-				time.Sleep(ExpectedTime - PastTime)
+				fr.cs.clock.Sleep(ExpectedTime - PastTime)
 				if fr.lastReqMessage == nil {
 					IsEnd = true
 				}
@@ -581,3 +581,9 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 	}
 	return nil
 }
+
+// AddObserverPeer adds the observer peer which is connected outside of the node
+// the ID of the peer should be the public hash of the observer
+func (fr *FormulatorNode) AddObserverPeer(p peer.Peer) {
+	fr.ms.AddPeer(p)
+}
@@ -1,8 +1,6 @@
 package pof
 
 import (
-	"time"
-
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/service/p2p"
 )
@@ -92,7 +90,7 @@ func (fr *FormulatorNode) sendRequestBlockTo(TargetID string, Height uint32, Cou
 	}
 	fr.ms.SendTo(TargetID, nm)
 	for i := uint32(0); i < uint32(Count); i++ {
-		fr.requestTimer.Add(Height+i, 2*time.Second, TargetID)
+		fr.requestTimer.Add(Height+i, fr.cs.config.requestTimeout(), TargetID)
 	}
 	return nil
 }
@@ -109,7 +107,7 @@ func (fr *FormulatorNode) sendRequestBlockToNode(TargetPubHash common.PublicHash
 	}
 	fr.sendMessage(0, TargetPubHash, nm)
 	for i := uint32(0); i < uint32(Count); i++ {
-		fr.requestTimer.Add(Height+i, 2*time.Second, string(TargetPubHash[:]))
+		fr.requestTimer.Add(Height+i, fr.cs.config.requestTimeout(), string(TargetPubHash[:]))
 	}
 	return nil
 }
@@ -39,11 +39,31 @@ func NewFormulatorService(ob *ObserverNode) *FormulatorService {
 
 // Run provides a server
 func (ms *FormulatorService) Run(BindAddress string) {
+	if len(BindAddress) == 0 {
+		return
+	}
 	if err := ms.server(BindAddress); err != nil {
 		panic(err)
 	}
 }
 
+// AddPeer adds a formulator peer which is connected outside of the service and handles it until it is closed
+func (ms *FormulatorService) AddPeer(p peer.Peer) {
+	ID := p.ID()
+	ms.RemovePeer(ID)
+	ms.Lock()
+	ms.peerMap[ID] = p
+	ms.Unlock()
+
+	go func() {
+		defer ms.RemovePeer(p.ID())
+
+		if err := ms.handleConnection(p); err != nil {
+			rlog.Println("[handleConnection]", err)
+		}
+	}()
+}
+
 // PeerCount returns a number of the peer
 func (ms *FormulatorService) PeerCount() int {
 	ms.Lock()
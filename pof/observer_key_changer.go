@@ -0,0 +1,20 @@
+package pof
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ObserverKeyHistoryDepth is the number of blocks of which observer key sets are kept to validate evidences
+const ObserverKeyHistoryDepth = 172800
+
+// ObserverKeyChanger is a process that schedules observer key changes
+type ObserverKeyChanger interface {
+	types.Process
+	ObserverKeyChanges(loader types.Loader, Height uint32) ([]common.PublicHash, []common.PublicHash, error)
+}
+
+type observerKeySet struct {
+	Height uint32
+	KeyMap *types.PublicHashBoolMap
+}
@@ -41,11 +41,17 @@ func NewObserverNodeMesh(key key.Key, NetAddressMap map[common.PublicHash]string
 func (ms *ObserverNodeMesh) Run(BindAddress string) {
 	myPublicHash := common.NewPublicHash(ms.key.PublicKey())
 	for PubHash, v := range ms.netAddressMap {
-		if PubHash != myPublicHash {
+		if PubHash != myPublicHash && len(v) > 0 {
 			go func(pubhash common.PublicHash, NetAddr string) {
 				time.Sleep(1 * time.Second)
 				for {
 					ID := string(pubhash[:])
+					if !ms.isObserver(pubhash) {
+						// the key is removed or not activated yet on the chain
+						ms.RemovePeer(ID)
+						time.Sleep(1 * time.Second)
+						continue
+					}
 					ms.Lock()
 					_, hasC := ms.clientPeerMap[ID]
 					_, hasS := ms.serverPeerMap[ID]
@@ -60,11 +66,52 @@ func (ms *ObserverNodeMesh) Run(BindAddress string) {
 			}(PubHash, v)
 		}
 	}
+	if len(BindAddress) == 0 {
+		return
+	}
 	if err := ms.server(BindAddress); err != nil {
 		panic(err)
 	}
 }
 
+// Connection returns the connectivity of the observer
+func (ms *ObserverNodeMesh) Connection(pubhash common.PublicHash) *ObserverConnection {
+	ms.Lock()
+	defer ms.Unlock()
+
+	ID := string(pubhash[:])
+	oc := &ObserverConnection{
+		PublicHash: pubhash,
+	}
+	if p, has := ms.clientPeerMap[ID]; has {
+		oc.IsClient = true
+		oc.ConnectedTime = p.ConnectedTime()
+	}
+	if p, has := ms.serverPeerMap[ID]; has {
+		oc.IsServer = true
+		oc.ConnectedTime = p.ConnectedTime()
+	}
+	oc.IsConnected = oc.IsClient || oc.IsServer
+	return oc
+}
+
+// AddPeer adds a peer which is connected outside of the mesh and handles it until it is closed
+func (ms *ObserverNodeMesh) AddPeer(p peer.Peer) {
+	ID := p.ID()
+	ms.removePeerInMap(ID, ms.clientPeerMap)
+	ms.Lock()
+	ms.clientPeerMap[ID] = p
+	ms.Unlock()
+
+	go func() {
+		defer ms.removePeerInMap(p.ID(), ms.clientPeerMap)
+
+		if err := ms.handleConnection(p); err != nil {
+			rlog.Println("[handleConnection]", err)
+		}
+	}()
+}
+
 // Peers returns peers of the observer mesh
 func (ms *ObserverNodeMesh) Peers() []peer.Peer {
 	peerMap := map[string]peer.Peer{}
@@ -199,7 +246,7 @@ func (ms *ObserverNodeMesh) client(Address string, TargetPubHash common.PublicHa
 	if pubhash != TargetPubHash {
 		return common.ErrInvalidPublicHash
 	}
-	if _, has := ms.netAddressMap[pubhash]; !has {
+	if !ms.isObserver(pubhash) {
 		return ErrInvalidObserverKey
 	}
 
@@ -237,7 +284,7 @@ func (ms *ObserverNodeMesh) server(BindAddress string) error {
 				rlog.Println("[sendHandshake]", err)
 				return
 			}
-			if _, has := ms.netAddressMap[pubhash]; !has {
+			if !ms.isObserver(pubhash) {
 				rlog.Println("ErrInvalidPublicHash", pubhash)
 				return
 			}
@@ -261,6 +308,11 @@ func (ms *ObserverNodeMesh) server(BindAddress string) error {
 	}
 }
 
+// isObserver returns the key is in the current observer set of the chain
+func (ms *ObserverNodeMesh) isObserver(pubhash common.PublicHash) bool {
+	return ms.ob.cs.ObserverKeyMap().Has(pubhash)
+}
+
 func (ms *ObserverNodeMesh) handleConnection(p peer.Peer) error {
 	if debug.DEBUG {
 		rlog.Println("Observer", common.NewPublicHash(ms.key.PublicKey()).String(), "Observer Connected", p.Name())
@@ -19,9 +19,11 @@ import (
 	"github.com/fletaio/fleta/service/p2p"
 )
 
-// BlockTime defines the block generation interval
+// BlockTime defines the default block generation interval
 const BlockTime = 500 * time.Millisecond
 
+const voteInterval = 100 * time.Millisecond
+
 type messageItem struct {
 	PublicHash common.PublicHash
 	Message    interface{}
@@ -76,7 +78,7 @@ func NewObserverNode(key key.Key, NetAddressMap map[common.PublicHash]string, cs
 	}
 	ob.ms = NewObserverNodeMesh(key, NetAddressMap, ob)
 	ob.fs = NewFormulatorService(ob)
-	ob.requestTimer = p2p.NewRequestTimer(ob)
+	ob.requestTimer = p2p.NewRequestTimerWithClock(ob, cs.clock)
 
 	rlog.SetRLogAddress("ob:" + ob.myPublicHash.String())
 	return ob
@@ -97,6 +99,10 @@ func (ob *ObserverNode) Init() error {
 	fc.Register(types.DefineHashedType("p2p.StatusMessage"), &p2p.StatusMessage{})
 	fc.Register(types.DefineHashedType("p2p.BlockMessage"), &p2p.BlockMessage{})
 	fc.Register(types.DefineHashedType("p2p.RequestMessage"), &p2p.RequestMessage{})
+
+	if err := ob.initAPI(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -175,14 +181,18 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 		}()
 	}
 
-	blockTimer := time.NewTimer(time.Millisecond)
-	queueTimer := time.NewTimer(time.Millisecond)
-	voteTimer := time.NewTimer(time.Millisecond)
+	blockTimer := ob.cs.clock.NewTimer(time.Millisecond)
+	queueTimer := ob.cs.clock.NewTimer(time.Millisecond)
+	voteTimer := ob.cs.clock.NewTimer(time.Millisecond)
 	for !ob.isClose {
 		select {
-		case <-blockTimer.C:
+		case <-blockTimer.C():
 			cp := ob.cs.cn.Provider()
 			ob.Lock()
+			if ob.isClose {
+				ob.Unlock()
+				break
+			}
 			hasItem := false
 			TargetHeight := uint64(cp.Height() + 1)
 			Count := 0
@@ -200,12 +210,15 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 					}
 				}
 				if err := ob.cs.cn.ConnectBlock(b, sm); err != nil {
+					if err == chain.ErrChainClosed {
+						break
+					}
 					rlog.Println(err)
 					panic(err)
 					break
 				}
 				if debug.DEBUG {
-					rlog.Println(cp.Height(), "BlockConnectedQ", b.Header.Generator.String(), ob.round.RoundState, b.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond), len(b.Transactions))
+					rlog.Println(cp.Height(), "BlockConnectedQ", b.Header.Generator.String(), ob.round.RoundState, b.Header.Height, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond), len(b.Transactions))
 				}
 				TargetHeight++
 				Count++
@@ -223,31 +236,37 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 			} else {
 				blockTimer.Reset(200 * time.Millisecond)
 			}
-		case <-queueTimer.C:
+		case <-queueTimer.C():
 			v := ob.messageQueue.Pop()
 			i := 0
 			for v != nil {
 				i++
 				item := v.(*messageItem)
 				ob.Lock()
-				ob.handleObserverMessage(item.PublicHash, item.Message, item.Packet)
+				if !ob.isClose {
+					ob.handleObserverMessage(item.PublicHash, item.Message, item.Packet)
+				}
 				ob.Unlock()
 				v = ob.messageQueue.Pop()
 			}
 			queueTimer.Reset(10 * time.Millisecond)
-		case <-voteTimer.C:
+		case <-voteTimer.C():
 			ob.Lock()
+			if ob.isClose {
+				ob.Unlock()
+				break
+			}
 			cp := ob.cs.cn.Provider()
 			ob.syncVoteRound()
 			IsFailable := true
 			if len(ob.adjustFormulatorMap()) > 0 {
 				if ob.round.MinRoundVoteAck != nil {
 					if debug.DEBUG {
-						rlog.Println(cp.Height(), "Current State", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+						rlog.Println(cp.Height(), "Current State", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 					}
 				} else {
 					if debug.DEBUG {
-						rlog.Println(cp.Height(), "Current State", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+						rlog.Println(cp.Height(), "Current State", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 					}
 				}
 				if ob.round.RoundState == RoundVoteState {
@@ -258,30 +277,30 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 					if has {
 						ob.sendBlockVote(br.BlockGenMessage)
 						if debug.DEBUG {
-							rlog.Println(cp.Height(), "sendBlockVote", ob.round.MinRoundVoteAck.Formulator.String(), encoding.Hash(br.BlockGenMessage.Block.Header), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+							rlog.Println(cp.Height(), "sendBlockVote", ob.round.MinRoundVoteAck.Formulator.String(), encoding.Hash(br.BlockGenMessage.Block.Header), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 						}
 						IsFailable = false
 					}
 				}
 				if IsFailable {
 					ob.round.VoteFailCount++
-					if ob.round.VoteFailCount > 20 {
+					if time.Duration(ob.round.VoteFailCount)*voteInterval > ob.cs.config.roundVoteTimeout() {
 						if ob.round.MinRoundVoteAck != nil {
 							/*
 								addr := ob.round.MinRoundVoteAck.Formulator
 								if _, has := ob.ignoreMap[addr]; has {
 									ob.fs.RemovePeer(string(addr[:]))
-									ob.ignoreMap[addr] = time.Now().UnixNano() + int64(120*time.Second)
+									ob.ignoreMap[addr] = ob.cs.clock.Now().UnixNano() + int64(120*time.Second)
 								} else {
-									ob.ignoreMap[addr] = time.Now().UnixNano() + int64(30*time.Second)
+									ob.ignoreMap[addr] = ob.cs.clock.Now().UnixNano() + int64(30*time.Second)
 								}
 							*/
 							if debug.DEBUG {
-								rlog.Println(cp.Height(), "Failure", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+								rlog.Println(cp.Height(), "Failure", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 							}
 						} else {
 							if debug.DEBUG {
-								rlog.Println(cp.Height(), "Failure", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+								rlog.Println(cp.Height(), "Failure", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 							}
 						}
 						ob.resetVoteRound(true)
@@ -289,12 +308,12 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 				}
 			} else {
 				if debug.DEBUG {
-					rlog.Println(cp.Height(), "No Formulator", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+					rlog.Println(cp.Height(), "No Formulator", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 				}
 			}
 			ob.Unlock()
 
-			voteTimer.Reset(100 * time.Millisecond)
+			voteTimer.Reset(voteInterval)
 		}
 	}
 }
@@ -342,7 +361,7 @@ func (ob *ObserverNode) addBlock(b *types.Block) error {
 func (ob *ObserverNode) adjustFormulatorMap() map[common.Address]bool {
 	FormulatorMap := ob.fs.FormulatorMap()
 	/*
-		now := time.Now().UnixNano()
+		now := ob.cs.clock.Now().UnixNano()
 		for addr := range FormulatorMap {
 			if now < ob.ignoreMap[addr] {
 				delete(FormulatorMap, addr)
@@ -375,7 +394,7 @@ func (ob *ObserverNode) syncVoteRound() {
 		}
 		if !IsContinue {
 			if debug.DEBUG {
-				rlog.Println(ob.cs.cn.Provider().Height(), "Turn Over", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+				rlog.Println(ob.cs.cn.Provider().Height(), "Turn Over", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 			}
 			ob.resetVoteRound(false)
 		}
@@ -384,7 +403,7 @@ func (ob *ObserverNode) syncVoteRound() {
 
 func (ob *ObserverNode) resetVoteRound(resetStat bool) {
 	ob.round = NewVoteRound(ob.cs.cn.Provider().Height()+1, ob.cs.maxBlocksPerFormulator)
-	ob.prevRoundEndTime = time.Now().UnixNano()
+	ob.prevRoundEndTime = ob.cs.clock.Now().UnixNano()
 	if resetStat {
 		ob.roundFirstTime = 0
 		ob.roundFirstHeight = 0
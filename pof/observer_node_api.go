@@ -0,0 +1,192 @@
+package pof
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+const maxTimeoutCountQuery = 1000
+
+// VoteRoundStatus is a snapshot of the vote round of the observer
+type VoteRoundStatus struct {
+	RoundState     int                   `json:"round_state"`
+	RoundStateName string                `json:"round_state_name"`
+	TargetHeight   uint32                `json:"target_height"`
+	VoteFailCount  int                   `json:"vote_fail_count"`
+	Observers      []*ObserverVoteStatus `json:"observers"`
+}
+
+// ObserverVoteStatus is collected votes of an observer in the vote round
+type ObserverVoteStatus struct {
+	PublicHash      common.PublicHash `json:"public_hash"`
+	HasRoundVote    bool              `json:"has_round_vote"`
+	HasRoundVoteAck bool              `json:"has_round_vote_ack"`
+	BlockVoteCount  int               `json:"block_vote_count"`
+	BlockVoteHeight []uint32          `json:"block_vote_heights"`
+}
+
+// ObserverConnection is a connectivity of an observer in the mesh
+type ObserverConnection struct {
+	PublicHash    common.PublicHash `json:"public_hash"`
+	IsConnected   bool              `json:"is_connected"`
+	IsClient      bool              `json:"is_client"`
+	IsServer      bool              `json:"is_server"`
+	ConnectedTime int64             `json:"connected_time"`
+}
+
+// TimeoutCountItem is a timeout count of the block
+type TimeoutCountItem struct {
+	Height       uint32 `json:"height"`
+	TimeoutCount uint32 `json:"timeout_count"`
+}
+
+func roundStateName(state int) string {
+	switch state {
+	case EmptyState:
+		return "EmptyState"
+	case RoundVoteState:
+		return "RoundVoteState"
+	case RoundVoteAckState:
+		return "RoundVoteAckState"
+	case BlockWaitState:
+		return "BlockWaitState"
+	case BlockVoteState:
+		return "BlockVoteState"
+	default:
+		return "UnknownState"
+	}
+}
+
+func (ob *ObserverNode) initAPI() error {
+	if vs, err := ob.cs.cn.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		js, err := v.JRPC("observer")
+		if err != nil {
+			return err
+		}
+		js.Set("getVoteRound", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return ob.VoteRoundStatus(), nil
+		})
+		js.Set("getFormulatorMap", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return ob.FormulatorList(), nil
+		})
+		js.Set("getObserverConnections", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return ob.ObserverConnections(), nil
+		})
+		js.Set("getTimeoutCounts", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			Count, err := arg.Uint32(0)
+			if err != nil {
+				return nil, err
+			}
+			if Count == 0 || Count > maxTimeoutCountQuery {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			return ob.TimeoutCounts(Count)
+		})
+	}
+	return nil
+}
+
+// VoteRoundStatus returns the snapshot of the current vote round
+func (ob *ObserverNode) VoteRoundStatus() *VoteRoundStatus {
+	ob.Lock()
+	defer ob.Unlock()
+
+	status := &VoteRoundStatus{
+		RoundState:     ob.round.RoundState,
+		RoundStateName: roundStateName(ob.round.RoundState),
+		TargetHeight:   ob.round.TargetHeight,
+		VoteFailCount:  ob.round.VoteFailCount,
+		Observers:      []*ObserverVoteStatus{},
+	}
+	heights := make([]uint32, 0, len(ob.round.BlockRoundMap))
+	for h := range ob.round.BlockRoundMap {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	for _, pubhash := range ob.observerKeys() {
+		vs := &ObserverVoteStatus{
+			PublicHash:      pubhash,
+			BlockVoteHeight: []uint32{},
+		}
+		_, vs.HasRoundVote = ob.round.RoundVoteMessageMap[pubhash]
+		_, vs.HasRoundVoteAck = ob.round.RoundVoteAckMessageMap[pubhash]
+		for _, h := range heights {
+			if _, has := ob.round.BlockRoundMap[h].BlockVoteMap[pubhash]; has {
+				vs.BlockVoteCount++
+				vs.BlockVoteHeight = append(vs.BlockVoteHeight, h)
+			}
+		}
+		status.Observers = append(status.Observers, vs)
+	}
+	return status
+}
+
+// FormulatorList returns addresses of formulators connected to the observer
+func (ob *ObserverNode) FormulatorList() []common.Address {
+	list := []common.Address{}
+	for addr := range ob.fs.FormulatorMap() {
+		list = append(list, addr)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i][:], list[j][:]) < 0
+	})
+	return list
+}
+
+// ObserverConnections returns the connectivity of other observers
+func (ob *ObserverNode) ObserverConnections() []*ObserverConnection {
+	list := []*ObserverConnection{}
+	for _, pubhash := range ob.observerKeys() {
+		if pubhash == ob.myPublicHash {
+			continue
+		}
+		list = append(list, ob.ms.Connection(pubhash))
+	}
+	return list
+}
+
+func (ob *ObserverNode) observerKeys() []common.PublicHash {
+	keys := []common.PublicHash{}
+	ob.cs.ObserverKeyMap().EachAll(func(pubhash common.PublicHash, value bool) bool {
+		keys = append(keys, pubhash)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	return keys
+}
+
+// TimeoutCounts returns timeout counts of the last blocks
+func (ob *ObserverNode) TimeoutCounts(Count uint32) ([]*TimeoutCountItem, error) {
+	provider := ob.cs.cn.Provider()
+	Height := provider.Height()
+	list := []*TimeoutCountItem{}
+	for h := Height; h > 0 && uint32(len(list)) < Count; h-- {
+		bh, err := provider.Header(h)
+		if err != nil {
+			return nil, err
+		}
+		TimeoutCount, err := ob.cs.DecodeConsensusData(bh.ConsensusData)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &TimeoutCountItem{
+			Height:       h,
+			TimeoutCount: TimeoutCount,
+		})
+	}
+	return list, nil
+}
@@ -152,3 +152,9 @@ func (ob *ObserverNode) handleFormulatorMessage(p peer.Peer, m interface{}, bs [
 	}
 	return nil
 }
+
+// AddFormulatorPeer adds the formulator peer which is connected outside of the node
+// the ID of the peer should be the address of the formulator
+func (ob *ObserverNode) AddFormulatorPeer(p peer.Peer) {
+	ob.fs.AddPeer(p)
+}
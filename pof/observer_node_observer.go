@@ -42,7 +42,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 
 	switch msg := m.(type) {
 	case *RoundVoteMessage:
-		if !ob.cs.observerKeyMap.Has(SenderPublicHash) {
+		if !ob.cs.ObserverKeyMap().Has(SenderPublicHash) {
 			return ErrInvalidObserverKey
 		}
 
@@ -105,10 +105,10 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		if !msg.RoundVote.IsReply && SenderPublicHash != ob.myPublicHash {
 			ob.sendRoundVoteTo(SenderPublicHash)
 		}
-		if len(ob.round.RoundVoteMessageMap) >= ob.cs.observerKeyMap.Len()/2+2 {
+		if len(ob.round.RoundVoteMessageMap) >= ob.cs.ObserverKeyMap().Len()/2+2 {
 			ob.round.RoundState = RoundVoteAckState
 			if ob.roundFirstTime == 0 {
-				ob.roundFirstTime = uint64(time.Now().UnixNano())
+				ob.roundFirstTime = uint64(ob.cs.clock.Now().UnixNano())
 				ob.roundFirstHeight = uint32(cp.Height())
 			}
 
@@ -122,8 +122,8 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			}
 		}
 	case *RoundVoteAckMessage:
-		//rlog.Println(cp.Height(), "RoundVoteAckMessage", ob.round.RoundState, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
-		if !ob.cs.observerKeyMap.Has(SenderPublicHash) {
+		//rlog.Println(cp.Height(), "RoundVoteAckMessage", ob.round.RoundState, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		if !ob.cs.ObserverKeyMap().Has(SenderPublicHash) {
 			return ErrInvalidObserverKey
 		}
 
@@ -186,13 +186,13 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		ob.round.RoundVoteAckMessageMap[SenderPublicHash] = msg
 
-		rlog.Println(ob.myPublicHash.String(), cp.Height(), "RoundVoteAckMessage", ob.round.RoundState, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		rlog.Println(ob.myPublicHash.String(), cp.Height(), "RoundVoteAckMessage", ob.round.RoundState, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		if !msg.RoundVoteAck.IsReply && SenderPublicHash != ob.myPublicHash {
 			ob.sendRoundVoteAckTo(SenderPublicHash)
 		}
 
-		if len(ob.round.RoundVoteAckMessageMap) >= ob.cs.observerKeyMap.Len()/2+1 {
+		if len(ob.round.RoundVoteAckMessageMap) >= ob.cs.ObserverKeyMap().Len()/2+1 {
 			var MinRoundVoteAck *RoundVoteAck
 			PublicHashCountMap := map[common.PublicHash]int{}
 			TimeoutCountMap := map[uint32]int{}
@@ -204,7 +204,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 				PublicHashCount := PublicHashCountMap[vt.PublicHash]
 				PublicHashCount++
 				PublicHashCountMap[vt.PublicHash] = PublicHashCount
-				if TimeoutCount >= ob.cs.observerKeyMap.Len()/2+1 && PublicHashCount >= ob.cs.observerKeyMap.Len()/2+1 {
+				if TimeoutCount >= ob.cs.ObserverKeyMap().Len()/2+1 && PublicHashCount >= ob.cs.ObserverKeyMap().Len()/2+1 {
 					MinRoundVoteAck = vt
 					break
 				}
@@ -257,7 +257,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			}
 		}
 	case *BlockGenMessage:
-		rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenMessage", ob.round.RoundState, msg.Block.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenMessage", ob.round.RoundState, msg.Block.Header.Height, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		//[check round]
 		br, has := ob.round.BlockRoundMap[msg.Block.Header.Height]
@@ -275,7 +275,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 				if len(raw) > 0 {
 					ob.ms.BroadcastPacket(raw)
 					if debug.DEBUG {
-						rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenBroadcast", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+						rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenBroadcast", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 					}
 				}
 			} else {
@@ -321,7 +321,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 						if NextTop != nil {
 							ob.sendMessagePacket(1, NextTop.Address, raw)
 							if debug.DEBUG {
-								rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenToNextTop", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+								rlog.Println(ob.myPublicHash.String(), cp.Height(), "BlockGenToNextTop", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 							}
 						}
 					}
@@ -376,7 +376,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 
 		//[if valid block]
-		Now := uint64(time.Now().UnixNano())
+		Now := uint64(ob.cs.clock.Now().UnixNano())
 		if msg.Block.Header.Timestamp > Now+uint64(10*time.Second) {
 			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.Timestamp > Now+uint64(10*time.Second) {")
 			return ErrInvalidVote
@@ -415,7 +415,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			})
 		}
 	case BlockGenRequestMessage:
-		if !ob.cs.observerKeyMap.Has(SenderPublicHash) {
+		if !ob.cs.ObserverKeyMap().Has(SenderPublicHash) {
 			return ErrInvalidObserverKey
 		}
 
@@ -463,8 +463,8 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			ob.sendBlockVoteTo(br.BlockGenMessage, SenderPublicHash)
 		}
 	case *BlockVoteMessage:
-		//rlog.Println(cp.Height(), encoding.Hash(msg.BlockVote.Header), "BlockVoteMessage", ob.round.RoundState, msg.BlockVote.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
-		if !ob.cs.observerKeyMap.Has(SenderPublicHash) {
+		//rlog.Println(cp.Height(), encoding.Hash(msg.BlockVote.Header), "BlockVoteMessage", ob.round.RoundState, msg.BlockVote.Header.Height, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		if !ob.cs.ObserverKeyMap().Has(SenderPublicHash) {
 			return ErrInvalidObserverKey
 		}
 
@@ -558,7 +558,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		br.BlockVoteMap[SenderPublicHash] = msg.BlockVote
 
-		rlog.Println("Observer", cp.Height(), encoding.Hash(msg.BlockVote.Header), "BlockVoteMessage", ob.round.RoundState, msg.BlockVote.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		rlog.Println("Observer", cp.Height(), encoding.Hash(msg.BlockVote.Header), "BlockVoteMessage", ob.round.RoundState, msg.BlockVote.Header.Height, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		//[check state]
 		if !msg.BlockVote.IsReply && SenderPublicHash != ob.myPublicHash {
@@ -566,20 +566,20 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 
 		//[apply vote]
-		if len(br.BlockVoteMap) >= ob.cs.observerKeyMap.Len()/2+1 {
+		if len(br.BlockVoteMap) >= ob.cs.ObserverKeyMap().Len()/2+1 {
 			sigs := []common.Signature{}
 			for _, vt := range br.BlockVoteMap {
 				sigs = append(sigs, vt.ObserverSignature)
 			}
 
-			PastTime := uint64(time.Now().UnixNano()) - ob.roundFirstTime
-			ExpectedTime := uint64(msg.BlockVote.Header.Height-ob.roundFirstHeight) * uint64(BlockTime)
+			PastTime := uint64(ob.cs.clock.Now().UnixNano()) - ob.roundFirstTime
+			ExpectedTime := uint64(msg.BlockVote.Header.Height-ob.roundFirstHeight) * uint64(ob.cs.config.blockTime())
 			if PastTime < ExpectedTime {
 				diff := time.Duration(ExpectedTime - PastTime)
-				if diff > BlockTime {
-					diff = BlockTime
+				if diff > ob.cs.config.blockTime() {
+					diff = ob.cs.config.blockTime()
 				}
-				time.Sleep(diff)
+				ob.cs.clock.Sleep(diff)
 			}
 
 			b := &types.Block{
@@ -641,7 +641,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 				}
 			}
 			if debug.DEBUG {
-				rlog.Println("Observer", cp.Height(), "BlockConnected", b.Header.Generator.String(), ob.round.RoundState, msg.BlockVote.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+				rlog.Println("Observer", cp.Height(), "BlockConnected", b.Header.Generator.String(), ob.round.RoundState, msg.BlockVote.Header.Height, (ob.cs.clock.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 			}
 
 			NextHeight := ob.round.TargetHeight + 1
@@ -732,3 +732,9 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 	}
 	return nil
 }
+
+// AddObserverPeer adds the observer peer which is connected outside of the node
+// the ID of the peer should be the public hash of the observer
+func (ob *ObserverNode) AddObserverPeer(p peer.Peer) {
+	ob.ms.AddPeer(p)
+}
@@ -2,7 +2,6 @@ package pof
 
 import (
 	"sort"
-	"time"
 
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/types"
@@ -44,7 +43,7 @@ func (ob *ObserverNode) sendRoundVote() error {
 			TimeoutCount:         uint32(TimeoutCount),
 			Formulator:           Top.Address,
 			FormulatorPublicHash: Top.PublicHash,
-			Timestamp:            uint64(time.Now().UnixNano()),
+			Timestamp:            uint64(ob.cs.clock.Now().UnixNano()),
 			IsReply:              false,
 		},
 	}
@@ -101,7 +100,7 @@ func (ob *ObserverNode) sendRoundVoteTo(TargetPubHash common.PublicHash) error {
 			nm.RoundVote.TimeoutCount = 0
 			nm.RoundVote.TargetHeight = TargetHeight
 			nm.RoundVote.LastHash = lastHash
-			nm.RoundVote.Timestamp = uint64(time.Now().UnixNano())
+			nm.RoundVote.Timestamp = uint64(ob.cs.clock.Now().UnixNano())
 		}
 
 		ob.ms.SendTo(TargetPubHash, p2p.MessageToPacket(nm))
@@ -119,7 +118,7 @@ func (ob *ObserverNode) sendRoundVoteTo(TargetPubHash common.PublicHash) error {
 				TimeoutCount:         uint32(TimeoutCount),
 				Formulator:           Top.Address,
 				FormulatorPublicHash: Top.PublicHash,
-				Timestamp:            uint64(time.Now().UnixNano()),
+				Timestamp:            uint64(ob.cs.clock.Now().UnixNano()),
 				IsReply:              true,
 			},
 		}
@@ -150,7 +149,7 @@ func (ob *ObserverNode) sendRoundVoteAck() error {
 			Formulator:           MinRoundVote.Formulator,
 			FormulatorPublicHash: MinRoundVote.FormulatorPublicHash,
 			PublicHash:           MinPublicHash,
-			Timestamp:            uint64(time.Now().UnixNano()),
+			Timestamp:            uint64(ob.cs.clock.Now().UnixNano()),
 			IsReply:              false,
 		},
 	}
@@ -194,7 +193,7 @@ func (ob *ObserverNode) sendRoundVoteAckTo(TargetPubHash common.PublicHash) erro
 			nm.RoundVoteAck.TimeoutCount = 0
 			nm.RoundVoteAck.TargetHeight = TargetHeight
 			nm.RoundVoteAck.LastHash = lastHash
-			nm.RoundVoteAck.Timestamp = uint64(time.Now().UnixNano())
+			nm.RoundVoteAck.Timestamp = uint64(ob.cs.clock.Now().UnixNano())
 		}
 
 		ob.ms.SendTo(TargetPubHash, p2p.MessageToPacket(nm))
@@ -288,8 +287,8 @@ func (ob *ObserverNode) sendBlockVoteTo(gen *BlockGenMessage, TargetPubHash comm
 }
 
 func (ob *ObserverNode) sendBlockGenRequest(br *BlockRound) error {
-	now := uint64(time.Now().UnixNano())
-	if br.LastBlockGenRequestTime+uint64(1*time.Second) > now {
+	now := uint64(ob.cs.clock.Now().UnixNano())
+	if br.LastBlockGenRequestTime+uint64(ob.cs.config.blockGenRequestTimeout()) > now {
 		return nil
 	}
 	br.LastBlockGenRequestTime = now
@@ -317,7 +316,7 @@ func (ob *ObserverNode) sendBlockGenRequest(br *BlockRound) error {
 			Formulator:           ob.round.MinRoundVoteAck.Formulator,
 			FormulatorPublicHash: ob.round.MinRoundVoteAck.FormulatorPublicHash,
 			PublicHash:           ob.round.MinRoundVoteAck.PublicHash,
-			Timestamp:            uint64(time.Now().UnixNano()),
+			Timestamp:            uint64(ob.cs.clock.Now().UnixNano()),
 		},
 	}
 	if has {
@@ -367,7 +366,7 @@ func (ob *ObserverNode) sendRequestBlockTo(TargetPubHash common.PublicHash, Heig
 	}
 	ob.ms.SendTo(TargetPubHash, p2p.MessageToPacket(nm))
 	for i := uint32(0); i < uint32(Count); i++ {
-		ob.requestTimer.Add(Height+i, 2*time.Second, string(TargetPubHash[:]))
+		ob.requestTimer.Add(Height+i, ob.cs.config.requestTimeout(), string(TargetPubHash[:]))
 	}
 	return nil
 }
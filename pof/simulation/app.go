@@ -0,0 +1,143 @@
+package simulation
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+type genesisFormulator struct {
+	Address common.Address
+	Name    string
+	KeyHash common.PublicHash
+	GenHash common.PublicHash
+}
+
+// simApp is the application of the simulation which creates formulators at the genesis
+type simApp struct {
+	*types.ApplicationBase
+	pm          types.ProcessManager
+	cn          types.Provider
+	addrMap     map[string]common.Address
+	formulators []*genesisFormulator
+}
+
+func newSimApp(AdminAddress common.Address, formulators []*genesisFormulator) *simApp {
+	return &simApp{
+		addrMap: map[string]common.Address{
+			"fleta.formulator": AdminAddress,
+			"fleta.vault":      AdminAddress,
+		},
+		formulators: formulators,
+	}
+}
+
+// Name returns the name of the application
+func (app *simApp) Name() string {
+	return "SimulationApp"
+}
+
+// Version returns the version of the application
+func (app *simApp) Version() string {
+	return "v1.0.0"
+}
+
+// Init initializes the application
+func (app *simApp) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	app.pm = pm
+	app.cn = cn
+	return nil
+}
+
+// InitGenesis initializes genesis data
+func (app *simApp) InitGenesis(ctw *types.ContextWrapper) error {
+	rewardPolicy := &formulator.RewardPolicy{
+		RewardPerBlock:        amount.NewCoinAmount(1, 0),
+		PayRewardEveryBlocks:  172800,
+		AlphaEfficiency1000:   1000,
+		SigmaEfficiency1000:   1150,
+		OmegaEfficiency1000:   1300,
+		HyperEfficiency1000:   1300,
+		StakingEfficiency1000: 700,
+	}
+	alphaPolicy := &formulator.AlphaPolicy{
+		AlphaCreationLimitHeight:  5184000,
+		AlphaCreationAmount:       amount.NewCoinAmount(200000, 0),
+		AlphaUnlockRequiredBlocks: 2592000,
+	}
+	sigmaPolicy := &formulator.SigmaPolicy{
+		SigmaRequiredAlphaBlocks:  5184000,
+		SigmaRequiredAlphaCount:   4,
+		SigmaUnlockRequiredBlocks: 2592000,
+	}
+	omegaPolicy := &formulator.OmegaPolicy{
+		OmegaRequiredSigmaBlocks:  5184000,
+		OmegaRequiredSigmaCount:   2,
+		OmegaUnlockRequiredBlocks: 2592000,
+	}
+	hyperPolicy := &formulator.HyperPolicy{
+		HyperCreationAmount:         amount.NewCoinAmount(5000000, 0),
+		HyperUnlockRequiredBlocks:   2592000,
+		StakingUnlockRequiredBlocks: 2592000,
+	}
+
+	if p, err := app.pm.ProcessByName("fleta.admin"); err != nil {
+		return err
+	} else if ap, is := p.(*admin.Admin); !is {
+		return types.ErrNotExistProcess
+	} else {
+		if err := ap.InitAdmin(ctw, app.addrMap); err != nil {
+			return err
+		}
+	}
+	if p, err := app.pm.ProcessByName("fleta.formulator"); err != nil {
+		return err
+	} else if fp, is := p.(*formulator.Formulator); !is {
+		return types.ErrNotExistProcess
+	} else {
+		if err := fp.InitPolicy(ctw,
+			rewardPolicy,
+			alphaPolicy,
+			sigmaPolicy,
+			omegaPolicy,
+			hyperPolicy,
+		); err != nil {
+			return err
+		}
+	}
+	if p, err := app.pm.ProcessByName("fleta.vault"); err != nil {
+		return err
+	} else if sp, is := p.(*vault.Vault); !is {
+		return types.ErrNotExistProcess
+	} else {
+		if err := sp.InitPolicy(ctw,
+			&vault.Policy{
+				AccountCreationAmount: amount.NewCoinAmount(10, 0),
+			},
+		); err != nil {
+			return err
+		}
+	}
+	for _, v := range app.formulators {
+		acc := &formulator.FormulatorAccount{
+			Address_:       v.Address,
+			Name_:          v.Name,
+			FormulatorType: formulator.AlphaFormulatorType,
+			KeyHash:        v.KeyHash,
+			GenHash:        v.GenHash,
+			Amount:         alphaPolicy.AlphaCreationAmount,
+		}
+		if err := ctw.CreateAccount(acc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnLoadChain called when the chain loaded
+func (app *simApp) OnLoadChain(loader types.LoaderWrapper) error {
+	return nil
+}
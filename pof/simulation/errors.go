@@ -0,0 +1,10 @@
+package simulation
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidConfig = errors.New("invalid config")
+	ErrWaitTimeout   = errors.New("wait timeout")
+	ErrNotConverged  = errors.New("not converged")
+)
@@ -0,0 +1,320 @@
+package simulation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/clock"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/pile"
+	"github.com/fletaio/fleta/pof"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/p2p"
+)
+
+// Config defines the topology of the simulated network
+// the clock is shared by all nodes when it is given
+type Config struct {
+	ObserverCount   int
+	FormulatorCount int
+	Consensus       pof.ConsensusConfig
+	Clock           clock.Clock
+	Seed            int64
+}
+
+// Node is a node of the simulated network
+type Node struct {
+	Name       string
+	Chain      *chain.Chain
+	Observer   *pof.ObserverNode
+	Formulator *pof.FormulatorNode
+	key        key.Key
+	address    common.Address
+}
+
+// Height returns the height of the chain of the node
+func (nd *Node) Height() uint32 {
+	return nd.Chain.Provider().Height()
+}
+
+// Simulation runs observers and formulators in the process which are connected by the MemoryNetwork
+type Simulation struct {
+	sync.Mutex
+	Network     *p2p.MemoryNetwork
+	Observers   []*Node
+	Formulators []*Node
+	storeRoot   string
+	isRunning   bool
+	isClose     bool
+}
+
+// New returns a Simulation that has a same genesis in all nodes
+func New(cfg *Config) (*Simulation, error) {
+	if cfg.ObserverCount <= 0 || cfg.FormulatorCount <= 0 {
+		return nil, ErrInvalidConfig
+	}
+	storeRoot, err := ioutil.TempDir("", "fleta_simulation_")
+	if err != nil {
+		return nil, err
+	}
+	sim := &Simulation{
+		Network:     p2p.NewMemoryNetwork(cfg.Seed),
+		Observers:   []*Node{},
+		Formulators: []*Node{},
+		storeRoot:   storeRoot,
+	}
+
+	ObserverKeys := []common.PublicHash{}
+	NetAddressMap := map[common.PublicHash]string{}
+	obkeys := []key.Key{}
+	for i := 0; i < cfg.ObserverCount; i++ {
+		Key, err := simKey(cfg.Seed, "observer", i)
+		if err != nil {
+			return nil, err
+		}
+		pubhash := common.NewPublicHash(Key.PublicKey())
+		ObserverKeys = append(ObserverKeys, pubhash)
+		NetAddressMap[pubhash] = ""
+		obkeys = append(obkeys, Key)
+	}
+
+	frkeys := []key.Key{}
+	formulators := []*genesisFormulator{}
+	for i := 0; i < cfg.FormulatorCount; i++ {
+		Key, err := simKey(cfg.Seed, "formulator", i)
+		if err != nil {
+			return nil, err
+		}
+		pubhash := common.NewPublicHash(Key.PublicKey())
+		formulators = append(formulators, &genesisFormulator{
+			Address: common.NewAddress(0, uint16(i+1), 0),
+			Name:    "formulator" + strconv.Itoa(i),
+			KeyHash: pubhash,
+			GenHash: pubhash,
+		})
+		frkeys = append(frkeys, Key)
+	}
+	AdminAddress := common.NewAddress(0, 0, 0)
+
+	for i, Key := range obkeys {
+		name := "observer" + strconv.Itoa(i)
+		cs := sim.newConsensus(cfg, ObserverKeys)
+		cn, err := sim.newChain(name, cs, AdminAddress, formulators)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		ob := pof.NewObserverNode(Key, NetAddressMap, cs)
+		if err := ob.Init(); err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.Observers = append(sim.Observers, &Node{
+			Name:     name,
+			Chain:    cn,
+			Observer: ob,
+			key:      Key,
+		})
+	}
+	for i, Key := range frkeys {
+		name := "formulator" + strconv.Itoa(i)
+		cs := sim.newConsensus(cfg, ObserverKeys)
+		cn, err := sim.newChain(name, cs, AdminAddress, formulators)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		ndkey, err := simKey(cfg.Seed, "node", i)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		fr := pof.NewFormulatorNode(&pof.FormulatorConfig{
+			Formulator: formulators[i].Address,
+		}, Key, ndkey, NetAddressMap, map[common.PublicHash]string{}, cs, filepath.Join(storeRoot, name, "peer"))
+		if err := fr.Init(); err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.Formulators = append(sim.Formulators, &Node{
+			Name:       name,
+			Chain:      cn,
+			Formulator: fr,
+			key:        Key,
+			address:    formulators[i].Address,
+		})
+	}
+	return sim, nil
+}
+
+func (sim *Simulation) newConsensus(cfg *Config, ObserverKeys []common.PublicHash) *pof.Consensus {
+	Config := cfg.Consensus
+	cs := pof.NewConsensus(&Config, ObserverKeys)
+	if cfg.Clock != nil {
+		cs.SetClock(cfg.Clock)
+	}
+	return cs
+}
+
+func (sim *Simulation) newChain(name string, cs *pof.Consensus, AdminAddress common.Address, formulators []*genesisFormulator) (*chain.Chain, error) {
+	back, err := backend.Create("buntdb", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	cdb, err := pile.Open(filepath.Join(sim.storeRoot, name, "chain"), hash.Hash256{}, 0, 0)
+	if err != nil {
+		back.Close()
+		return nil, err
+	}
+	st, err := chain.NewStore(back, cdb, 0xFF, "FLETA", "Simulation", 0x0001)
+	if err != nil {
+		back.Close()
+		cdb.Close()
+		return nil, err
+	}
+	cn := chain.NewChain(cs, newSimApp(AdminAddress, formulators), st)
+	cn.MustAddProcess(admin.NewAdmin(1))
+	cn.MustAddProcess(vault.NewVault(2))
+	fp := formulator.NewFormulator(3)
+	fp.SetConsensusState(cs)
+	cn.MustAddProcess(fp)
+	if err := cn.Init(hash.Hash256{}, hash.Hash256{}, 0, 0); err != nil {
+		st.Close()
+		return nil, err
+	}
+	return cn, nil
+}
+
+// Start runs all nodes and connects them in the full mesh through the network
+func (sim *Simulation) Start() {
+	sim.Lock()
+	defer sim.Unlock()
+
+	if sim.isRunning || sim.isClose {
+		return
+	}
+	sim.isRunning = true
+
+	for _, nd := range sim.Observers {
+		go nd.Observer.Run("", "")
+	}
+	for _, nd := range sim.Formulators {
+		go nd.Formulator.Run("")
+	}
+	for i, a := range sim.Observers {
+		apubhash := common.NewPublicHash(a.key.PublicKey())
+		for _, b := range sim.Observers[i+1:] {
+			bpubhash := common.NewPublicHash(b.key.PublicKey())
+			pa, pb := sim.Network.Pipe(a.Name, string(apubhash[:]), b.Name, string(bpubhash[:]))
+			a.Observer.AddObserverPeer(pa)
+			b.Observer.AddObserverPeer(pb)
+		}
+	}
+	for _, fr := range sim.Formulators {
+		for _, ob := range sim.Observers {
+			obpubhash := common.NewPublicHash(ob.key.PublicKey())
+			pf, po := sim.Network.Pipe(fr.Name, string(fr.address[:]), ob.Name, string(obpubhash[:]))
+			fr.Formulator.AddObserverPeer(pf)
+			ob.Observer.AddFormulatorPeer(po)
+		}
+	}
+}
+
+// Close terminates all nodes and removes their data
+func (sim *Simulation) Close() {
+	sim.Lock()
+	defer sim.Unlock()
+
+	if sim.isClose {
+		return
+	}
+	sim.isClose = true
+
+	groups := [][]string{}
+	for _, nd := range sim.Nodes() {
+		groups = append(groups, []string{nd.Name})
+	}
+	sim.Network.Partition(groups...)
+	for _, nd := range sim.Observers {
+		nd.Observer.Close()
+	}
+	for _, nd := range sim.Formulators {
+		nd.Formulator.Close()
+	}
+	os.RemoveAll(sim.storeRoot)
+}
+
+// Nodes returns all observers and formulators
+func (sim *Simulation) Nodes() []*Node {
+	nodes := make([]*Node, 0, len(sim.Observers)+len(sim.Formulators))
+	nodes = append(nodes, sim.Observers...)
+	nodes = append(nodes, sim.Formulators...)
+	return nodes
+}
+
+// WaitHeight waits until all nodes reach the height
+func (sim *Simulation) WaitHeight(Height uint32, timeout time.Duration) error {
+	return sim.WaitNodesHeight(sim.Nodes(), Height, timeout)
+}
+
+// WaitNodesHeight waits until the given nodes reach the height
+func (sim *Simulation) WaitNodesHeight(nodes []*Node, Height uint32, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		IsReached := true
+		for _, nd := range nodes {
+			if nd.Height() < Height {
+				IsReached = false
+				break
+			}
+		}
+		if IsReached {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrWaitTimeout
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// CheckConverged checks that all nodes have the same block hashes until the height
+func (sim *Simulation) CheckConverged(Height uint32) error {
+	nodes := sim.Nodes()
+	for h := uint32(1); h <= Height; h++ {
+		var expected hash.Hash256
+		for i, nd := range nodes {
+			v, err := nd.Chain.Provider().Hash(h)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				expected = v
+			} else if v != expected {
+				return ErrNotConverged
+			}
+		}
+	}
+	return nil
+}
+
+func simKey(Seed int64, role string, index int) (key.Key, error) {
+	bs := make([]byte, 0, 64)
+	bs = append(bs, binutil.LittleEndian.Uint64ToBytes(uint64(Seed))...)
+	bs = append(bs, []byte(role)...)
+	bs = append(bs, binutil.LittleEndian.Uint32ToBytes(uint32(index))...)
+	h := hash.Hash(bs)
+	return key.NewMemoryKeyFromBytes(h[:])
+}
@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta/common/clock"
+	"github.com/fletaio/fleta/pof"
+)
+
+func TestConverge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the simulation in short mode")
+	}
+
+	sim, err := New(&Config{
+		ObserverCount:   3,
+		FormulatorCount: 2,
+		Consensus: pof.ConsensusConfig{
+			MaxBlocksPerFormulator: 3,
+		},
+		Seed: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Close()
+
+	sim.Network.SetDelay(5*time.Millisecond, 5*time.Millisecond)
+	sim.Start()
+
+	if err := sim.WaitHeight(8, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckConverged(8); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConvergeAfterPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the simulation in short mode")
+	}
+
+	sim, err := New(&Config{
+		ObserverCount:   5,
+		FormulatorCount: 2,
+		Consensus: pof.ConsensusConfig{
+			MaxBlocksPerFormulator: 3,
+		},
+		Seed: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Close()
+
+	sim.Start()
+	if err := sim.WaitHeight(3, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// a round vote requires N/2+2 observers, so the others keep producing blocks while the isolated observer falls behind
+	sim.Network.Partition(
+		[]string{"observer0", "observer1", "observer2", "observer3", "formulator0", "formulator1"},
+		[]string{"observer4"},
+	)
+	sim.Network.SetDropRate(0.05)
+	Height := sim.Observers[0].Height() + 3
+	if err := sim.WaitNodesHeight(sim.Observers[:4], Height, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Network.Heal()
+	sim.Network.SetDropRate(0)
+	if err := sim.WaitHeight(Height+2, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckConverged(Height + 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManualClock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the simulation in short mode")
+	}
+
+	c := clock.NewManualClock(time.Now())
+	sim, err := New(&Config{
+		ObserverCount:   3,
+		FormulatorCount: 2,
+		Consensus: pof.ConsensusConfig{
+			MaxBlocksPerFormulator: 3,
+		},
+		Clock: c,
+		Seed:  3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var isPaused int32 = 1
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if atomic.LoadInt32(&isPaused) == 0 {
+				c.Advance(10 * time.Millisecond)
+			}
+			time.Sleep(2 * time.Millisecond)
+		}
+	}()
+	defer func() {
+		// nodes can wait for the clock while closing
+		atomic.StoreInt32(&isPaused, 0)
+		sim.Close()
+		close(done)
+	}()
+
+	sim.Start()
+
+	// nodes do not make progress while the clock is paused
+	time.Sleep(time.Second)
+	for _, nd := range sim.Nodes() {
+		if nd.Height() != 0 {
+			t.Fatal("block is generated without advancing the clock", nd.Name, nd.Height())
+		}
+	}
+
+	atomic.StoreInt32(&isPaused, 0)
+	if err := sim.WaitHeight(8, 60*time.Second); err != nil {
+		for _, nd := range sim.Nodes() {
+			t.Log(nd.Name, nd.Height())
+		}
+		t.Fatal(err)
+	}
+	if err := sim.CheckConverged(8); err != nil {
+		t.Fatal(err)
+	}
+}
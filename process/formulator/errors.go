@@ -43,4 +43,15 @@ var (
 	ErrNoOverAmount                            = errors.New("no over amount")
 	ErrSigmaCreationNotAllowed                 = errors.New("sigma creation not allowed")
 	ErrOmegaCreationNotAllowed                 = errors.New("omega creation not allowed")
+	ErrInvalidActivationHeight                 = errors.New("invalid activation height")
+	ErrInvalidObserverKeyChange                = errors.New("invalid observer key change")
+	ErrNotExistSlashingPolicy                  = errors.New("not exist slashing policy")
+	ErrInvalidSlashingPolicy                   = errors.New("invalid slashing policy")
+	ErrInvalidEvidence                         = errors.New("invalid evidence")
+	ErrAlreadyReportedEvidence                 = errors.New("already reported evidence")
+	ErrNotExistConsensusState                  = errors.New("not exist consensus state")
+	ErrNotRankedFormulator                     = errors.New("not ranked formulator")
+	ErrNotObserverKey                          = errors.New("not observer key")
+	ErrExistObserverKey                        = errors.New("exist observer key")
+	ErrPendingObserverKey                      = errors.New("pending observer key")
 )
@@ -0,0 +1,89 @@
+package formulator
+
+import (
+	"bytes"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// ConsensusState provides the state of the consensus that is required to validate evidences
+// It should read the state from the chain data of the loader, not from the memory of the node,
+// so every node gives the same result to the transaction at the same height
+type ConsensusState interface {
+	IsObserverKeyAt(loader types.Loader, Height uint32, pubhash common.PublicHash) (bool, error)
+	ObserverKeysAt(loader types.Loader, Height uint32) ([]common.PublicHash, error)
+	IsFormulator(loader types.Loader, Formulator common.Address, Publichash common.PublicHash) (bool, error)
+}
+
+// BlockGenSigner returns the public hash of the generator that signed the header
+func BlockGenSigner(bh *types.Header, GeneratorSignature common.Signature) (common.PublicHash, error) {
+	pubkey, err := common.RecoverPubkey(encoding.Hash(bh), GeneratorSignature)
+	if err != nil {
+		return common.PublicHash{}, err
+	}
+	return common.NewPublicHash(pubkey), nil
+}
+
+// BlockVoteSigner returns the public hash of the observer that voted the header
+func BlockVoteSigner(bh *types.Header, GeneratorSignature common.Signature, ObserverSignature common.Signature) (common.PublicHash, error) {
+	s := &types.BlockSign{
+		HeaderHash:         encoding.Hash(bh),
+		GeneratorSignature: GeneratorSignature,
+	}
+	pubkey, err := common.RecoverPubkey(encoding.Hash(s), ObserverSignature)
+	if err != nil {
+		return common.PublicHash{}, err
+	}
+	return common.NewPublicHash(pubkey), nil
+}
+
+// validateConflictingHeaders checks that headers are different blocks of the same round and returns the evidence hash
+func validateConflictingHeaders(loader types.Loader, HeaderA *types.Header, HeaderB *types.Header) (hash.Hash256, error) {
+	if HeaderA == nil || HeaderB == nil {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	if HeaderA.Height != HeaderB.Height {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	if HeaderA.Generator != HeaderB.Generator {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	if !bytes.Equal(HeaderA.ConsensusData, HeaderB.ConsensusData) {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	if HeaderA.Height > loader.TargetHeight() {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	if HeaderA.ChainID != loader.ChainID() || HeaderB.ChainID != loader.ChainID() {
+		return hash.Hash256{}, ErrInvalidEvidence
+	}
+	HashA := encoding.Hash(HeaderA)
+	HashB := encoding.Hash(HeaderB)
+	switch bytes.Compare(HashA[:], HashB[:]) {
+	case 0:
+		return hash.Hash256{}, ErrInvalidEvidence
+	case 1:
+		HashA, HashB = HashB, HashA
+	}
+	return hash.Hashes(HashA, HashB), nil
+}
+
+// validateRankedGenerator checks that the generator of the header is a formulator of the rank table that signed the header
+func (p *Formulator) validateRankedGenerator(loader types.Loader, bh *types.Header, GeneratorSignature common.Signature) (common.PublicHash, error) {
+	if p.cs == nil {
+		return common.PublicHash{}, ErrNotExistConsensusState
+	}
+	pubhash, err := BlockGenSigner(bh, GeneratorSignature)
+	if err != nil {
+		return common.PublicHash{}, err
+	}
+	if IsFormulator, err := p.cs.IsFormulator(loader, bh.Generator, pubhash); err != nil {
+		return common.PublicHash{}, err
+	} else if !IsFormulator {
+		return common.PublicHash{}, ErrNotRankedFormulator
+	}
+	return pubhash, nil
+}
@@ -0,0 +1,253 @@
+package formulator
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+type testLoader struct {
+	accs map[common.Address]types.Account
+}
+
+func (l *testLoader) ChainID() uint8                           { return 1 }
+func (l *testLoader) Name() string                             { return "test" }
+func (l *testLoader) Version() uint16                          { return 1 }
+func (l *testLoader) TargetHeight() uint32                     { return 10 }
+func (l *testLoader) LastHash() hash.Hash256                   { return hash.Hash256{} }
+func (l *testLoader) LastTimestamp() uint64                    { return 0 }
+func (l *testLoader) HasAccountName(Name string) (bool, error) { return false, nil }
+func (l *testLoader) HasUTXO(id uint64) (bool, error)          { return false, nil }
+func (l *testLoader) UTXO(id uint64) (*types.UTXO, error)      { return nil, types.ErrNotExistUTXO }
+func (l *testLoader) IsUsedTimeSlot(slot uint32, key string) bool {
+	return false
+}
+func (l *testLoader) AccountData(addr common.Address, pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) ProcessData(pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) AddressByName(Name string) (common.Address, error) {
+	return common.Address{}, types.ErrNotExistAccount
+}
+func (l *testLoader) Account(addr common.Address) (types.Account, error) {
+	if acc, has := l.accs[addr]; has {
+		return acc, nil
+	}
+	return nil, types.ErrNotExistAccount
+}
+func (l *testLoader) HasAccount(addr common.Address) (bool, error) {
+	_, has := l.accs[addr]
+	return has, nil
+}
+
+type testConsensusState struct {
+	observerKeys map[common.PublicHash]bool
+	formulators  map[common.Address]common.PublicHash
+}
+
+func (cs *testConsensusState) IsObserverKeyAt(loader types.Loader, Height uint32, pubhash common.PublicHash) (bool, error) {
+	return cs.observerKeys[pubhash], nil
+}
+
+func (cs *testConsensusState) ObserverKeysAt(loader types.Loader, Height uint32) ([]common.PublicHash, error) {
+	keys := []common.PublicHash{}
+	for pubhash, has := range cs.observerKeys {
+		if has {
+			keys = append(keys, pubhash)
+		}
+	}
+	return keys, nil
+}
+
+func (cs *testConsensusState) IsFormulator(loader types.Loader, Formulator common.Address, Publichash common.PublicHash) (bool, error) {
+	pubhash, has := cs.formulators[Formulator]
+	return has && pubhash == Publichash, nil
+}
+
+type testEvidence struct {
+	t         *testing.T
+	ctx       *types.Context
+	p         *Formulator
+	adminAddr common.Address
+	genAddr   common.Address
+	genKey    *key.MemoryKey
+	obKey     *key.MemoryKey
+}
+
+// newTestEvidence prepares the formulator process of which the observer set has obKey and the rank table has genKey
+func newTestEvidence(t *testing.T) *testEvidence {
+	adminAddr := common.NewAddress(0, 1, 0)
+	genAddr := common.NewAddress(0, 2, 0)
+	genKey, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obKey, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ld := &testLoader{accs: map[common.Address]types.Account{
+		adminAddr: &vault.SingleAccount{Address_: adminAddr, KeyHash: common.PublicHash{1}},
+	}}
+	ctx := types.NewContext(ld)
+	ad := admin.NewAdmin(1)
+	v := vault.NewVault(2)
+	p := NewFormulator(3)
+	p.admin = ad
+	p.vault = v
+	p.SetConsensusState(&testConsensusState{
+		observerKeys: map[common.PublicHash]bool{common.NewPublicHash(obKey.PublicKey()): true},
+		formulators:  map[common.Address]common.PublicHash{genAddr: common.NewPublicHash(genKey.PublicKey())},
+	})
+	if err := ad.InitAdmin(types.NewContextWrapper(1, ctx), map[string]common.Address{p.Name(): adminAddr}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.AddBalance(types.NewContextWrapper(2, ctx), adminAddr, amount.NewCoinAmount(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+	return &testEvidence{t: t, ctx: ctx, p: p, adminAddr: adminAddr, genAddr: genAddr, genKey: genKey, obKey: obKey}
+}
+
+// vote returns a header of the round that is signed by the generator and voted by the observer key
+func (te *testEvidence) vote(obKey *key.MemoryKey, TimeoutCount uint32, Timestamp uint64) (*types.Header, common.Signature, common.Signature) {
+	ConsensusData, err := encoding.Marshal(TimeoutCount)
+	if err != nil {
+		te.t.Fatal(err)
+	}
+	bh := &types.Header{
+		ChainID:       1,
+		Version:       1,
+		Height:        5,
+		Timestamp:     Timestamp,
+		Generator:     te.genAddr,
+		ConsensusData: ConsensusData,
+	}
+	GeneratorSignature, err := te.genKey.Sign(encoding.Hash(bh))
+	if err != nil {
+		te.t.Fatal(err)
+	}
+	bs := &types.BlockSign{
+		HeaderHash:         encoding.Hash(bh),
+		GeneratorSignature: GeneratorSignature,
+	}
+	ObserverSignature, err := obKey.Sign(encoding.Hash(bs))
+	if err != nil {
+		te.t.Fatal(err)
+	}
+	return bh, GeneratorSignature, ObserverSignature
+}
+
+func (te *testEvidence) slashObserver(obKey *key.MemoryKey, TimeoutCountA uint32, TimeoutCountB uint32) *SlashObserver {
+	tx := &SlashObserver{
+		Timestamp_: 1,
+		From_:      te.adminAddr,
+	}
+	tx.HeaderA, tx.GeneratorSignatureA, tx.ObserverSignatureA = te.vote(obKey, TimeoutCountA, 1)
+	tx.HeaderB, tx.GeneratorSignatureB, tx.ObserverSignatureB = te.vote(obKey, TimeoutCountB, 2)
+	return tx
+}
+
+func (te *testEvidence) validate(tx types.Transaction) error {
+	return tx.Validate(te.p, types.NewLoaderWrapper(te.p.ID(), te.ctx), []common.PublicHash{common.PublicHash{1}})
+}
+
+func TestSlashObserver(t *testing.T) {
+	te := newTestEvidence(t)
+	tx := te.slashObserver(te.obKey, 0, 0)
+	if err := te.validate(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Execute(te.p, types.NewContextWrapper(te.p.ID(), te.ctx), 1); err != nil {
+		t.Fatal(err)
+	}
+	AddKeys, RemoveKeys, err := te.p.ObserverKeyChanges(te.ctx, te.ctx.TargetHeight()+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(AddKeys) != 0 || len(RemoveKeys) != 1 || RemoveKeys[0] != common.NewPublicHash(te.obKey.PublicKey()) {
+		t.Fatalf("invalid observer key change %v %v", AddKeys, RemoveKeys)
+	}
+	if err := te.validate(tx); err != ErrAlreadyReportedEvidence {
+		t.Fatalf("expected %v but %v", ErrAlreadyReportedEvidence, err)
+	}
+}
+
+func TestSlashObserverNotObserver(t *testing.T) {
+	te := newTestEvidence(t)
+	otherKey, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := te.slashObserver(otherKey, 0, 0)
+	if err := te.validate(tx); err != ErrNotObserverKey {
+		t.Fatalf("expected %v but %v", ErrNotObserverKey, err)
+	}
+}
+
+func TestSlashObserverDifferentRounds(t *testing.T) {
+	te := newTestEvidence(t)
+	tx := te.slashObserver(te.obKey, 0, 1)
+	if err := te.validate(tx); err != ErrInvalidEvidence {
+		t.Fatalf("expected %v but %v", ErrInvalidEvidence, err)
+	}
+}
+
+func TestSlashFormulatorRemainingStake(t *testing.T) {
+	for _, isRevoking := range []bool{false, true} {
+		te := newTestEvidence(t)
+		ctw := types.NewContextWrapper(te.p.ID(), te.ctx)
+		frAddr := common.NewAddress(te.ctx.TargetHeight(), 1, 0)
+		frAcc := &FormulatorAccount{
+			Address_:       frAddr,
+			Name_:          "test1",
+			FormulatorType: AlphaFormulatorType,
+			KeyHash:        common.PublicHash{2},
+			GenHash:        common.NewPublicHash(te.genKey.PublicKey()),
+			Amount:         amount.NewCoinAmount(1000, 0),
+			StakingAmount:  amount.NewCoinAmount(0, 0),
+		}
+		if err := ctw.CreateAccount(frAcc); err != nil {
+			t.Fatal(err)
+		}
+		if err := te.p.vault.AddBalance(ctw, frAddr, amount.NewCoinAmount(5, 0)); err != nil {
+			t.Fatal(err)
+		}
+		if isRevoking {
+			if err := te.p.addRevokedFormulator(ctw, frAddr, 100, te.adminAddr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		AdminBalance := te.p.vault.Balance(ctw, te.adminAddr)
+		if err := te.p.slashFormulator(ctw, frAddr, 100, 1); err != nil {
+			t.Fatal(err)
+		}
+		if has, _ := ctw.HasAccount(frAddr); has {
+			t.Fatal("slashed formulator is not revoked")
+		}
+		Inherited := te.p.vault.Balance(ctw, te.adminAddr).Sub(AdminBalance)
+		if isRevoking {
+			if !Inherited.Equal(amount.NewCoinAmount(905, 0)) {
+				t.Fatal("invalid inherited amount", Inherited.String())
+			}
+			if _, err := te.p.GetRevokedFormulatorHeight(ctw, frAddr); err != ErrNotRevoked {
+				t.Fatalf("expected %v but %v", ErrNotRevoked, err)
+			}
+		} else {
+			if !Inherited.IsZero() {
+				t.Fatal("remaining stake is inherited without the revoke", Inherited.String())
+			}
+			if !te.p.vault.Balance(ctw, frAddr).IsZero() {
+				t.Fatal("balance of the slashed formulator remains")
+			}
+		}
+	}
+}
@@ -17,6 +17,7 @@ type Formulator struct {
 	cn    types.Provider
 	vault *vault.Vault
 	admin *admin.Admin
+	cs    ConsensusState
 }
 
 // NewFormulator returns a Formulator
@@ -42,6 +43,12 @@ func (p *Formulator) Version() string {
 	return "0.0.1"
 }
 
+// SetConsensusState sets the consensus state that is used to validate evidences
+// it should be called before the chain is initialized
+func (p *Formulator) SetConsensusState(cs ConsensusState) {
+	p.cs = cs
+}
+
 // Init initializes the process
 func (p *Formulator) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
 	p.pm = pm
@@ -89,10 +96,16 @@ func (p *Formulator) Init(reg *types.Register, pm types.ProcessManager, cn types
 	reg.RegisterTransaction(24, &UpdateOmegaPolicy{})
 	reg.RegisterTransaction(25, &RevokeToBEP20{})
 	reg.RegisterTransaction(26, &RecalcHyperAdmin{})
+	reg.RegisterTransaction(27, &UpdateObserverKeys{})
+	reg.RegisterTransaction(28, &UpdateSlashingPolicy{})
+	reg.RegisterTransaction(29, &SlashFormulator{})
+	reg.RegisterTransaction(30, &SlashObserver{})
 	reg.RegisterEvent(1, &RewardEvent{})
 	reg.RegisterEvent(2, &RevokedEvent{})
 	reg.RegisterEvent(3, &UnstakedEvent{})
 	reg.RegisterEvent(4, &RevokeToBEP20Event{})
+	reg.RegisterEvent(5, &SlashedEvent{})
+	reg.RegisterEvent(6, &ObserverSlashedEvent{})
 	return nil
 }
 
@@ -180,6 +193,11 @@ func (p *Formulator) BeforeExecuteTransactions(ctw *types.ContextWrapper) error
 func (p *Formulator) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
 	p.addGenCount(ctw, b.Header.Generator)
 
+	// the consensus applies the change of the next height when the block is saved
+	if err := p.flushPendingObserverKeys(ctw, ctw.TargetHeight()+1); err != nil {
+		return err
+	}
+
 	policy := &RewardPolicy{}
 	if err := encoding.Unmarshal(ctw.ProcessData(tagRewardPolicy), &policy); err != nil {
 		return err
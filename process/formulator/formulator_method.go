@@ -1,9 +1,13 @@
 package formulator
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/encoding"
 )
@@ -597,3 +601,177 @@ func (p *Formulator) processFormulatorMiningReward(ctw *types.ContextWrapper, ev
 		return nil
 	}
 }
+
+// ObserverKeyChanges returns observer keys that are added or removed at the height
+func (p *Formulator) ObserverKeyChanges(loader types.Loader, Height uint32) ([]common.PublicHash, []common.PublicHash, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toObserverKeyChangeKey(Height))
+	if len(bs) == 0 {
+		return nil, nil, nil
+	}
+	change := &ObserverKeyChange{}
+	if err := encoding.Unmarshal(bs, &change); err != nil {
+		return nil, nil, err
+	}
+	return change.AddKeys, change.RemoveKeys, nil
+}
+
+func (p *Formulator) addObserverKeyChange(ctw *types.ContextWrapper, Height uint32, AddKeys []common.PublicHash, RemoveKeys []common.PublicHash) error {
+	change := &ObserverKeyChange{}
+	if bs := ctw.ProcessData(toObserverKeyChangeKey(Height)); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &change); err != nil {
+			return err
+		}
+	}
+	change.AddKeys = append(change.AddKeys, AddKeys...)
+	change.RemoveKeys = append(change.RemoveKeys, RemoveKeys...)
+	if bs, err := encoding.Marshal(change); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toObserverKeyChangeKey(Height), bs)
+	}
+	for _, pubhash := range AddKeys {
+		p.setPendingObserverKey(ctw, pubhash, Height)
+	}
+	for _, pubhash := range RemoveKeys {
+		p.setPendingObserverKey(ctw, pubhash, Height)
+	}
+	return nil
+}
+
+// IsPendingObserverKey returns the key is in the observer key change that is not activated yet or not
+func (p *Formulator) IsPendingObserverKey(loader types.Loader, pubhash common.PublicHash) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return len(lw.ProcessData(toPendingObserverKeyKey(pubhash))) > 0
+}
+
+// setPendingObserverKey keeps the last activation height of changes of the key
+func (p *Formulator) setPendingObserverKey(ctw *types.ContextWrapper, pubhash common.PublicHash, Height uint32) {
+	if bs := ctw.ProcessData(toPendingObserverKeyKey(pubhash)); len(bs) > 0 && binutil.LittleEndian.Uint32(bs) >= Height {
+		return
+	}
+	ctw.SetProcessData(toPendingObserverKeyKey(pubhash), binutil.LittleEndian.Uint32ToBytes(Height))
+}
+
+// flushPendingObserverKeys clears pending marks of keys that are activated at the height
+func (p *Formulator) flushPendingObserverKeys(ctw *types.ContextWrapper, Height uint32) error {
+	AddKeys, RemoveKeys, err := p.ObserverKeyChanges(ctw, Height)
+	if err != nil {
+		return err
+	}
+	for _, pubhash := range append(AddKeys, RemoveKeys...) {
+		if bs := ctw.ProcessData(toPendingObserverKeyKey(pubhash)); len(bs) > 0 && binutil.LittleEndian.Uint32(bs) == Height {
+			ctw.SetProcessData(toPendingObserverKeyKey(pubhash), nil)
+		}
+	}
+	return nil
+}
+
+// GetSlashingPolicy returns the slashing policy
+func (p *Formulator) GetSlashingPolicy(loader types.Loader) (*SlashingPolicy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagSlashingPolicy)
+	if len(bs) == 0 {
+		return nil, ErrNotExistSlashingPolicy
+	}
+
+	policy := &SlashingPolicy{}
+	if err := encoding.Unmarshal(bs, &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// IsReportedEvidence returns the evidence is already reported or not
+func (p *Formulator) IsReportedEvidence(loader types.Loader, EvidenceHash hash.Hash256) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return len(lw.ProcessData(toSlashingEvidenceKey(EvidenceHash))) > 0
+}
+
+func (p *Formulator) setReportedEvidence(ctw *types.ContextWrapper, EvidenceHash hash.Hash256) {
+	ctw.SetProcessData(toSlashingEvidenceKey(EvidenceHash), []byte{1})
+}
+
+// slashFormulator burns the ratio of stakes and revokes the formulator
+// the remaining stake goes to the heritor of the pending revoke that the owner decided, or it is burned when the formulator is not in the revoke
+func (p *Formulator) slashFormulator(ctw *types.ContextWrapper, FormulatorAddr common.Address, BurnRatio1000 uint32, index uint16) error {
+	acc, err := ctw.Account(FormulatorAddr)
+	if err != nil {
+		return err
+	}
+	frAcc, is := acc.(*FormulatorAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+
+	BurnedAmount := frAcc.Amount.MulC(int64(BurnRatio1000)).DivC(1000)
+	frAcc.Amount = frAcc.Amount.Sub(BurnedAmount)
+
+	// the formulator inherits nothing from itself when the remaining stake is burned
+	Heritor := FormulatorAddr
+	if RevokeHeight, err := p.GetRevokedFormulatorHeight(ctw, FormulatorAddr); err != nil {
+		if err != ErrNotRevoked {
+			return err
+		}
+		BurnedAmount = BurnedAmount.Add(frAcc.Amount).Add(p.vault.Balance(ctw, FormulatorAddr))
+		frAcc.Amount = amount.NewCoinAmount(0, 0)
+		if err := p.vault.RemoveBalance(ctw, FormulatorAddr); err != nil {
+			return err
+		}
+	} else {
+		Heritor, err = p.getRevokedFormulatorHeritor(ctw, FormulatorAddr, RevokeHeight)
+		if err != nil {
+			return err
+		}
+		if err := p.removeRevokedFormulator(ctw, FormulatorAddr); err != nil {
+			return err
+		}
+	}
+
+	BurnedStakingAmount := amount.NewCoinAmount(0, 0)
+	if frAcc.FormulatorType == HyperFormulatorType {
+		StakingAmountMap, err := p.GetStakingAmountMap(ctw, FormulatorAddr)
+		if err != nil {
+			return err
+		}
+		StakingAddresses := make([]common.Address, 0, len(StakingAmountMap))
+		for addr := range StakingAmountMap {
+			StakingAddresses = append(StakingAddresses, addr)
+		}
+		sort.Slice(StakingAddresses, func(i, j int) bool {
+			return bytes.Compare(StakingAddresses[i][:], StakingAddresses[j][:]) < 0
+		})
+		for _, addr := range StakingAddresses {
+			Burned := StakingAmountMap[addr].MulC(int64(BurnRatio1000)).DivC(1000)
+			if Burned.IsZero() {
+				continue
+			}
+			if err := p.subStakingAmount(ctw, FormulatorAddr, addr, Burned); err != nil {
+				return err
+			}
+			frAcc.StakingAmount = frAcc.StakingAmount.Sub(Burned)
+			BurnedStakingAmount = BurnedStakingAmount.Add(Burned)
+		}
+	}
+
+	ev := &SlashedEvent{
+		Height_:             ctw.TargetHeight(),
+		Index_:              index,
+		Formulator:          FormulatorAddr,
+		BurnedAmount:        BurnedAmount,
+		BurnedStakingAmount: BurnedStakingAmount,
+	}
+	if err := ctw.EmitEvent(ev); err != nil {
+		return err
+	}
+
+	frAcc.IsRevoked = true
+	if err := p.revokeFormulator(ctw, FormulatorAddr, Heritor); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package formulator
+
+import (
+	"github.com/fletaio/fleta/common"
+)
+
+// ObserverKeyChange defines observer keys that are added or removed at the activation height
+type ObserverKeyChange struct {
+	AddKeys    []common.PublicHash
+	RemoveKeys []common.PublicHash
+}
+
+// observerKeyChangeMessage is the message that current observers sign to authorize the observer key change
+type observerKeyChangeMessage struct {
+	ChainID          uint8
+	ActivationHeight uint32
+	AddKeys          []common.PublicHash
+	RemoveKeys       []common.PublicHash
+}
@@ -312,3 +312,22 @@ func (pc *MiningFeePolicy) MarshalJSON() ([]byte, error) {
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
+
+// SlashingPolicy defines a slashing policy
+type SlashingPolicy struct {
+	BurnRatio1000 uint32
+}
+
+// MarshalJSON is a marshaler function
+func (pc *SlashingPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"burn_ratio_1000":`)
+	if bs, err := json.Marshal(pc.BurnRatio1000); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
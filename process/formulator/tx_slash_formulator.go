@@ -0,0 +1,172 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// SlashFormulator is used to punish the formulator that generated two different blocks at the same height
+// The remaining stake goes to the heritor of the pending revoke of the formulator or it is burned
+type SlashFormulator struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Formulator common.Address
+	HeaderA    *types.Header
+	SignatureA common.Signature
+	HeaderB    *types.Header
+	SignatureB common.Signature
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SlashFormulator) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *SlashFormulator) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *SlashFormulator) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Formulator)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *SlashFormulator) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if _, err := sp.GetSlashingPolicy(loader); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	acc, err := loader.Account(tx.Formulator)
+	if err != nil {
+		return err
+	}
+	frAcc, is := acc.(*FormulatorAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+
+	EvidenceHash, err := validateConflictingHeaders(loader, tx.HeaderA, tx.HeaderB)
+	if err != nil {
+		return err
+	}
+	if tx.HeaderA.Generator != tx.Formulator || tx.HeaderB.Generator != tx.Formulator {
+		return ErrInvalidEvidence
+	}
+	if pubhash, err := sp.validateRankedGenerator(loader, tx.HeaderA, tx.SignatureA); err != nil {
+		return err
+	} else if pubhash != frAcc.GenHash {
+		return ErrInvalidEvidence
+	}
+	if pubhash, err := sp.validateRankedGenerator(loader, tx.HeaderB, tx.SignatureB); err != nil {
+		return err
+	} else if pubhash != frAcc.GenHash {
+		return ErrInvalidEvidence
+	}
+	if sp.IsReportedEvidence(loader, EvidenceHash) {
+		return ErrAlreadyReportedEvidence
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SlashFormulator) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Formulator)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		EvidenceHash, err := validateConflictingHeaders(ctw, tx.HeaderA, tx.HeaderB)
+		if err != nil {
+			return err
+		}
+		sp.setReportedEvidence(ctw, EvidenceHash)
+
+		policy, err := sp.GetSlashingPolicy(ctw)
+		if err != nil {
+			return err
+		}
+		if err := sp.slashFormulator(ctw, tx.Formulator, policy.BurnRatio1000, index); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SlashFormulator) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"formulator":`)
+	if bs, err := tx.Formulator.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header_a":`)
+	if bs, err := json.Marshal(tx.HeaderA); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"signature_a":`)
+	if bs, err := tx.SignatureA.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header_b":`)
+	if bs, err := json.Marshal(tx.HeaderB); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"signature_b":`)
+	if bs, err := tx.SignatureB.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
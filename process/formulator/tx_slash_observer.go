@@ -0,0 +1,195 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// SlashObserver is used to remove the observer that voted two different blocks of the same round
+type SlashObserver struct {
+	Timestamp_          uint64
+	From_               common.Address
+	HeaderA             *types.Header
+	GeneratorSignatureA common.Signature
+	ObserverSignatureA  common.Signature
+	HeaderB             *types.Header
+	GeneratorSignatureB common.Signature
+	ObserverSignatureB  common.Signature
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SlashObserver) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *SlashObserver) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *SlashObserver) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Formulator)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// ObserverPublicHash returns the public hash of the observer that signed both votes
+func (tx *SlashObserver) ObserverPublicHash() (common.PublicHash, error) {
+	PublicHashA, err := BlockVoteSigner(tx.HeaderA, tx.GeneratorSignatureA, tx.ObserverSignatureA)
+	if err != nil {
+		return common.PublicHash{}, err
+	}
+	PublicHashB, err := BlockVoteSigner(tx.HeaderB, tx.GeneratorSignatureB, tx.ObserverSignatureB)
+	if err != nil {
+		return common.PublicHash{}, err
+	}
+	if PublicHashA != PublicHashB {
+		return common.PublicHash{}, ErrInvalidEvidence
+	}
+	return PublicHashA, nil
+}
+
+// Validate validates signatures of the transaction
+func (tx *SlashObserver) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	EvidenceHash, err := validateConflictingHeaders(loader, tx.HeaderA, tx.HeaderB)
+	if err != nil {
+		return err
+	}
+	if _, err := sp.validateRankedGenerator(loader, tx.HeaderA, tx.GeneratorSignatureA); err != nil {
+		return err
+	}
+	if _, err := sp.validateRankedGenerator(loader, tx.HeaderB, tx.GeneratorSignatureB); err != nil {
+		return err
+	}
+	pubhash, err := tx.ObserverPublicHash()
+	if err != nil {
+		return err
+	}
+	if IsObserver, err := sp.cs.IsObserverKeyAt(loader, tx.HeaderA.Height, pubhash); err != nil {
+		return err
+	} else if !IsObserver {
+		return ErrNotObserverKey
+	}
+	if sp.IsReportedEvidence(loader, EvidenceHash) {
+		return ErrAlreadyReportedEvidence
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SlashObserver) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Formulator)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		EvidenceHash, err := validateConflictingHeaders(ctw, tx.HeaderA, tx.HeaderB)
+		if err != nil {
+			return err
+		}
+		sp.setReportedEvidence(ctw, EvidenceHash)
+
+		pubhash, err := tx.ObserverPublicHash()
+		if err != nil {
+			return err
+		}
+		ActivationHeight := ctw.TargetHeight() + 1
+		if err := sp.addObserverKeyChange(ctw, ActivationHeight, nil, []common.PublicHash{pubhash}); err != nil {
+			return err
+		}
+		ev := &ObserverSlashedEvent{
+			Height_:            ctw.TargetHeight(),
+			Index_:             index,
+			ObserverPublicHash: pubhash,
+			ActivationHeight:   ActivationHeight,
+		}
+		if err := ctw.EmitEvent(ev); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SlashObserver) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header_a":`)
+	if bs, err := json.Marshal(tx.HeaderA); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"generator_signature_a":`)
+	if bs, err := tx.GeneratorSignatureA.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"observer_signature_a":`)
+	if bs, err := tx.ObserverSignatureA.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header_b":`)
+	if bs, err := json.Marshal(tx.HeaderB); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"generator_signature_b":`)
+	if bs, err := tx.GeneratorSignatureB.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"observer_signature_b":`)
+	if bs, err := tx.ObserverSignatureB.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,206 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// UpdateObserverKeys is used to add, remove or rotate observer keys at the activation height
+// It is authorized by the admin or by signatures of the majority of current observers over the change
+type UpdateObserverKeys struct {
+	Timestamp_         uint64
+	From_              common.Address
+	ActivationHeight   uint32
+	AddKeys            []common.PublicHash
+	RemoveKeys         []common.PublicHash
+	ObserverSignatures []common.Signature
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateObserverKeys) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateObserverKeys) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *UpdateObserverKeys) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Formulator)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// ChangeHash returns the hash that current observers sign to authorize the change
+func (tx *UpdateObserverKeys) ChangeHash(ChainID uint8) hash.Hash256 {
+	return encoding.Hash(&observerKeyChangeMessage{
+		ChainID:          ChainID,
+		ActivationHeight: tx.ActivationHeight,
+		AddKeys:          tx.AddKeys,
+		RemoveKeys:       tx.RemoveKeys,
+	})
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateObserverKeys) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if sp.cs == nil {
+		return ErrNotExistConsensusState
+	}
+	if len(tx.ObserverSignatures) == 0 {
+		if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+			return admin.ErrUnauthorizedTransaction
+		}
+	} else {
+		keys, err := sp.cs.ObserverKeysAt(loader, loader.TargetHeight())
+		if err != nil {
+			return err
+		}
+		KeyMap := map[common.PublicHash]bool{}
+		for _, pubhash := range keys {
+			KeyMap[pubhash] = true
+		}
+		if err := common.ValidateSignaturesMajority(tx.ChangeHash(loader.ChainID()), tx.ObserverSignatures, KeyMap); err != nil {
+			return err
+		}
+	}
+	if tx.ActivationHeight <= loader.TargetHeight() {
+		return ErrInvalidActivationHeight
+	}
+	if len(tx.AddKeys) == 0 && len(tx.RemoveKeys) == 0 {
+		return ErrInvalidObserverKeyChange
+	}
+	var emptyHash common.PublicHash
+	keyMap := map[common.PublicHash]bool{}
+	for _, pubhash := range tx.AddKeys {
+		if pubhash == emptyHash || keyMap[pubhash] {
+			return ErrInvalidObserverKeyChange
+		}
+		keyMap[pubhash] = true
+	}
+	for _, pubhash := range tx.RemoveKeys {
+		if pubhash == emptyHash || keyMap[pubhash] {
+			return ErrInvalidObserverKeyChange
+		}
+		keyMap[pubhash] = true
+	}
+	// a key of the pending change is rejected so the current set is the set in force at the activation height for keys of the change
+	for _, pubhash := range tx.AddKeys {
+		if sp.IsPendingObserverKey(loader, pubhash) {
+			return ErrPendingObserverKey
+		}
+		if IsObserver, err := sp.cs.IsObserverKeyAt(loader, loader.TargetHeight(), pubhash); err != nil {
+			return err
+		} else if IsObserver {
+			return ErrExistObserverKey
+		}
+	}
+	for _, pubhash := range tx.RemoveKeys {
+		if sp.IsPendingObserverKey(loader, pubhash) {
+			return ErrPendingObserverKey
+		}
+		if IsObserver, err := sp.cs.IsObserverKeyAt(loader, loader.TargetHeight(), pubhash); err != nil {
+			return err
+		} else if !IsObserver {
+			return ErrNotObserverKey
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateObserverKeys) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Formulator)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		return sp.addObserverKeyChange(ctw, tx.ActivationHeight, tx.AddKeys, tx.RemoveKeys)
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateObserverKeys) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"activation_height":`)
+	if bs, err := json.Marshal(tx.ActivationHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"add_keys":[`)
+	for i, pubhash := range tx.AddKeys {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"remove_keys":[`)
+	for i, pubhash := range tx.RemoveKeys {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"observer_signatures":[`)
+	for i, sig := range tx.ObserverSignatures {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
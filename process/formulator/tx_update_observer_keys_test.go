@@ -0,0 +1,130 @@
+package formulator
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+func TestUpdateObserverKeys(t *testing.T) {
+	te := newTestEvidence(t)
+	obHash := common.NewPublicHash(te.obKey.PublicKey())
+	newHash := common.PublicHash{2}
+
+	tx := &UpdateObserverKeys{
+		Timestamp_:       1,
+		From_:            te.adminAddr,
+		ActivationHeight: te.ctx.TargetHeight() + 1,
+		AddKeys:          []common.PublicHash{newHash},
+		RemoveKeys:       []common.PublicHash{obHash},
+	}
+	if err := te.validate(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.AddKeys = []common.PublicHash{obHash}
+	tx.RemoveKeys = nil
+	if err := te.validate(tx); err != ErrExistObserverKey {
+		t.Fatalf("expected %v but %v", ErrExistObserverKey, err)
+	}
+
+	tx.AddKeys = nil
+	tx.RemoveKeys = []common.PublicHash{newHash}
+	if err := te.validate(tx); err != ErrNotObserverKey {
+		t.Fatalf("expected %v but %v", ErrNotObserverKey, err)
+	}
+}
+
+func TestUpdateObserverKeysPending(t *testing.T) {
+	te := newTestEvidence(t)
+	obHash := common.NewPublicHash(te.obKey.PublicKey())
+	newHash := common.PublicHash{2}
+
+	tx := &UpdateObserverKeys{
+		Timestamp_:       1,
+		From_:            te.adminAddr,
+		ActivationHeight: te.ctx.TargetHeight() + 2,
+		AddKeys:          []common.PublicHash{newHash},
+	}
+	if err := te.validate(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Execute(te.p, types.NewContextWrapper(te.p.ID(), te.ctx), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	other := &UpdateObserverKeys{
+		Timestamp_:       2,
+		From_:            te.adminAddr,
+		ActivationHeight: te.ctx.TargetHeight() + 1,
+		RemoveKeys:       []common.PublicHash{obHash},
+		AddKeys:          []common.PublicHash{newHash},
+	}
+	if err := te.validate(other); err != ErrPendingObserverKey {
+		t.Fatalf("expected %v but %v", ErrPendingObserverKey, err)
+	}
+
+	ctw := types.NewContextWrapper(te.p.ID(), te.ctx)
+	if err := te.p.flushPendingObserverKeys(ctw, te.ctx.TargetHeight()+1); err != nil {
+		t.Fatal(err)
+	}
+	if !te.p.IsPendingObserverKey(ctw, newHash) {
+		t.Fatal("pending key is cleared before the activation height")
+	}
+	if err := te.p.flushPendingObserverKeys(ctw, te.ctx.TargetHeight()+2); err != nil {
+		t.Fatal(err)
+	}
+	if te.p.IsPendingObserverKey(ctw, newHash) {
+		t.Fatal("pending key is not cleared at the activation height")
+	}
+}
+
+func TestUpdateObserverKeysByObservers(t *testing.T) {
+	te := newTestEvidence(t)
+	newHash := common.PublicHash{2}
+	ctw := types.NewContextWrapper(te.p.ID(), te.ctx)
+	userAddr := common.NewAddress(te.ctx.TargetHeight(), 1, 0)
+	if err := ctw.CreateAccount(&vault.SingleAccount{Address_: userAddr, Name_: "test1", KeyHash: common.PublicHash{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.p.vault.AddBalance(ctw, userAddr, amount.NewCoinAmount(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &UpdateObserverKeys{
+		Timestamp_:       1,
+		From_:            userAddr,
+		ActivationHeight: te.ctx.TargetHeight() + 1,
+		AddKeys:          []common.PublicHash{newHash},
+	}
+	if err := te.validate(tx); err != admin.ErrUnauthorizedTransaction {
+		t.Fatalf("expected %v but %v", admin.ErrUnauthorizedTransaction, err)
+	}
+
+	otherKey, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := otherKey.Sign(tx.ChangeHash(te.ctx.ChainID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.ObserverSignatures = []common.Signature{sig}
+	if err := te.validate(tx); err != common.ErrInvalidSignature {
+		t.Fatalf("expected %v but %v", common.ErrInvalidSignature, err)
+	}
+
+	sig, err = te.obKey.Sign(tx.ChangeHash(te.ctx.ChainID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.ObserverSignatures = []common.Signature{sig}
+	if err := te.validate(tx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,6 +3,7 @@ package formulator
 import (
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
 )
 
 // tags
@@ -38,6 +39,10 @@ var (
 	tagUnstakingAmountReverse   = []byte{6, 2}
 	tagUnstakingAmountCount     = []byte{6, 3}
 	tagRewardBaseUpgrade        = []byte{7, 0}
+	tagObserverKeyChange        = []byte{8, 0}
+	tagPendingObserverKey       = []byte{8, 1}
+	tagSlashingPolicy           = []byte{9, 0}
+	tagSlashingEvidence         = []byte{9, 1}
 )
 
 func toStakingAmountKey(StakingAddrss common.Address) []byte {
@@ -150,3 +155,24 @@ func toUnstakingAmountCountKey(height uint32) []byte {
 	binutil.BigEndian.PutUint32(bs[2:], height)
 	return bs
 }
+
+func toObserverKeyChangeKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagObserverKeyChange)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
+
+func toPendingObserverKeyKey(pubhash common.PublicHash) []byte {
+	bs := make([]byte, 2+common.PublicHashSize)
+	copy(bs, tagPendingObserverKey)
+	copy(bs[2:], pubhash[:])
+	return bs
+}
+
+func toSlashingEvidenceKey(h hash.Hash256) []byte {
+	bs := make([]byte, 2+hash.Hash256Size)
+	copy(bs, tagSlashingEvidence)
+	copy(bs[2:], h[:])
+	return bs
+}
@@ -16,4 +16,10 @@ var (
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
 	ErrNotSupportedPlatform             = errors.New("not supported platform")
 	ErrAlreadySupportedPlatform         = errors.New("already supported platform")
+	ErrInvalidValidatorSet              = errors.New("invalid validator set")
+	ErrNotExistValidatorSet             = errors.New("not exist validator set")
+	ErrInvalidAttestation               = errors.New("invalid attestation")
+	ErrDuplicatedAttestation            = errors.New("duplicated attestation")
+	ErrInsufficientAttestation          = errors.New("insufficient attestation")
+	ErrExceedDailyCap                   = errors.New("exceed daily cap")
 )
@@ -1,6 +1,9 @@
 package gateway
 
 import (
+	"time"
+
+	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/binutil"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/core/types"
@@ -143,3 +146,115 @@ func (p *Gateway) GetGatewayPolicy(loader types.Loader) (*Policy, error) {
 	}
 	return policy, nil
 }
+
+// ValidatorSet returns the bridge validator set of the platform
+func (p *Gateway) ValidatorSet(loader types.Loader, Platform string) (*ValidatorSet, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toPlatformKey(Platform)); len(bs) == 0 {
+		return nil, ErrNotSupportedPlatform
+	}
+	bs := lw.ProcessData(toValidatorSetKey(Platform))
+	if len(bs) == 0 {
+		return nil, ErrNotExistValidatorSet
+	}
+	vs := &ValidatorSet{}
+	if err := encoding.Unmarshal(bs, &vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// ValidatorSetSeq returns the number of validator set updates of the platform
+func (p *Gateway) ValidatorSetSeq(loader types.Loader, Platform string) uint64 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toValidatorSeqKey(Platform))
+	if len(bs) == 0 {
+		return 0
+	}
+	return binutil.LittleEndian.Uint64(bs)
+}
+
+// InitValidatorSet called at OnInitGenesis of an application
+func (p *Gateway) InitValidatorSet(ctw *types.ContextWrapper, Platform string, vs *ValidatorSet) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	return p.SetValidatorSet(ctw, Platform, vs)
+}
+
+// SetValidatorSet sets the bridge validator set of the platform
+func (p *Gateway) SetValidatorSet(ctw *types.ContextWrapper, Platform string, vs *ValidatorSet) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if bs := ctw.ProcessData(toPlatformKey(Platform)); len(bs) == 0 {
+		return ErrNotSupportedPlatform
+	}
+	if err := vs.Validate(); err != nil {
+		return err
+	}
+	if bs, err := encoding.Marshal(vs); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toValidatorSetKey(Platform), bs)
+	}
+	Seq := p.ValidatorSetSeq(ctw, Platform) + 1
+	ctw.SetProcessData(toValidatorSeqKey(Platform), binutil.LittleEndian.Uint64ToBytes(Seq))
+	return nil
+}
+
+type dailyInflow struct {
+	Day    uint64
+	Amount *amount.Amount
+}
+
+// DailyInflow returns the deposit amount of the platform at the day of the timestamp
+func (p *Gateway) DailyInflow(loader types.Loader, Platform string, Timestamp uint64) (*amount.Amount, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toDailyInflowKey(Platform))
+	if len(bs) == 0 {
+		return amount.NewCoinAmount(0, 0), nil
+	}
+	inflow := &dailyInflow{}
+	if err := encoding.Unmarshal(bs, &inflow); err != nil {
+		return nil, err
+	}
+	if inflow.Day != Timestamp/uint64(24*time.Hour) {
+		return amount.NewCoinAmount(0, 0), nil
+	}
+	return inflow.Amount, nil
+}
+
+// CheckDailyInflow returns the deposit amount of the day with the amount when it is not over the daily cap of the validator set
+func (p *Gateway) CheckDailyInflow(loader types.LoaderWrapper, Platform string, vs *ValidatorSet, am *amount.Amount) (*amount.Amount, error) {
+	sum, err := p.DailyInflow(loader, Platform, loader.LastTimestamp())
+	if err != nil {
+		return nil, err
+	}
+	sum = sum.Add(am)
+	if !vs.DailyCap.IsZero() && vs.DailyCap.Less(sum) {
+		return nil, ErrExceedDailyCap
+	}
+	return sum, nil
+}
+
+// addDailyInflow accumulates the deposit amount of the day and checks the daily cap of the validator set
+func (p *Gateway) addDailyInflow(ctw *types.ContextWrapper, Platform string, vs *ValidatorSet, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	Timestamp := ctw.LastTimestamp()
+	sum, err := p.CheckDailyInflow(ctw, Platform, vs, am)
+	if err != nil {
+		return err
+	}
+	if bs, err := encoding.Marshal(&dailyInflow{
+		Day:    Timestamp / uint64(24*time.Hour),
+		Amount: sum,
+	}); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toDailyInflowKey(Platform), bs)
+	}
+	return nil
+}
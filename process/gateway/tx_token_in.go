@@ -8,18 +8,22 @@ import (
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
 	"github.com/fletaio/fleta/process/admin"
 )
 
 // TokenIn is a TokenIn
+// If the platform has a validator set, it should be attested by the validators and any account can submit it
+// Otherwise only the admin of the process can submit it
 type TokenIn struct {
-	Timestamp_  uint64
-	From_       common.Address
-	Platform    string
-	ERC20TXID   hash.Hash256
-	ERC20From   ERC20Address
-	ToAddresses []common.Address
-	Amounts     []*amount.Amount
+	Timestamp_   uint64
+	From_        common.Address
+	Platform     string
+	ERC20TXID    hash.Hash256
+	ERC20From    ERC20Address
+	ToAddresses  []common.Address
+	Amounts      []*amount.Amount
+	Attestations []common.Signature `msgpack:",omitempty"`
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -32,12 +36,44 @@ func (tx *TokenIn) From() common.Address {
 	return tx.From_
 }
 
+// AttestationHash returns the hash that validators sign to attest the deposit
+func (tx *TokenIn) AttestationHash(ChainID uint8) hash.Hash256 {
+	return encoding.Hash(&tokenInMessage{
+		ChainID:     ChainID,
+		Platform:    tx.Platform,
+		ERC20TXID:   tx.ERC20TXID,
+		ERC20From:   tx.ERC20From,
+		ToAddresses: tx.ToAddresses,
+		Amounts:     tx.Amounts,
+	})
+}
+
+func (tx *TokenIn) totalAmount() *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for _, am := range tx.Amounts {
+		sum = sum.Add(am)
+	}
+	return sum
+}
+
 // Validate validates signatures of the transaction
 func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
+	if vs, err := sp.ValidatorSet(loader, tx.Platform); err != nil {
+		if err != ErrNotExistValidatorSet {
+			return err
+		}
+		if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+			return admin.ErrUnauthorizedTransaction
+		}
+	} else {
+		if err := vs.CheckAttestations(tx.AttestationHash(loader.ChainID()), tx.Attestations); err != nil {
+			return err
+		}
+		if _, err := sp.CheckDailyInflow(loader, tx.Platform, vs, tx.totalAmount()); err != nil {
+			return err
+		}
 	}
 	if len(tx.Amounts) != len(tx.ToAddresses) {
 		return ErrInvalidAddressCount
@@ -76,8 +112,18 @@ func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers
 func (tx *TokenIn) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Gateway)
 
+	if vs, err := sp.ValidatorSet(ctw, tx.Platform); err != nil {
+		if err != ErrNotExistValidatorSet {
+			return err
+		}
+	} else {
+		if err := sp.addDailyInflow(ctw, tx.Platform, vs, tx.totalAmount()); err != nil {
+			return err
+		}
+	}
+	AdminAddress := sp.admin.AdminAddress(ctw, p.Name())
 	for i, am := range tx.Amounts {
-		if err := sp.vault.SubBalance(ctw, tx.From(), am); err != nil {
+		if err := sp.vault.SubBalance(ctw, AdminAddress, am); err != nil {
 			return err
 		}
 		if err := sp.vault.AddBalance(ctw, tx.ToAddresses[i], am); err != nil {
@@ -156,6 +202,20 @@ func (tx *TokenIn) MarshalJSON() ([]byte, error) {
 		}
 	}
 	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"attestations":`)
+	buffer.WriteString(`[`)
+	for i, sig := range tx.Attestations {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -8,19 +8,23 @@ import (
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
 	"github.com/fletaio/fleta/process/admin"
 )
 
 // TokenLeave is a TokenLeave
+// If the platform has a validator set, it should be attested by the validators and any account can submit it
+// Otherwise only the admin of the process can submit it
 type TokenLeave struct {
-	Timestamp_ uint64
-	From_      common.Address
-	Platform   string
-	CoinTXID   string
-	CoinFrom   common.Address
-	ERC20TXID  hash.Hash256
-	ERC20To    ERC20Address
-	Amount     *amount.Amount
+	Timestamp_   uint64
+	From_        common.Address
+	Platform     string
+	CoinTXID     string
+	CoinFrom     common.Address
+	ERC20TXID    hash.Hash256
+	ERC20To      ERC20Address
+	Amount       *amount.Amount
+	Attestations []common.Signature `msgpack:",omitempty"`
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -33,12 +37,34 @@ func (tx *TokenLeave) From() common.Address {
 	return tx.From_
 }
 
+// AttestationHash returns the hash that validators sign to attest the leave
+func (tx *TokenLeave) AttestationHash(ChainID uint8) hash.Hash256 {
+	return encoding.Hash(&tokenLeaveMessage{
+		ChainID:   ChainID,
+		Platform:  tx.Platform,
+		CoinTXID:  tx.CoinTXID,
+		CoinFrom:  tx.CoinFrom,
+		ERC20TXID: tx.ERC20TXID,
+		ERC20To:   tx.ERC20To,
+		Amount:    tx.Amount,
+	})
+}
+
 // Validate validates signatures of the transaction
 func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
+	if vs, err := sp.ValidatorSet(loader, tx.Platform); err != nil {
+		if err != ErrNotExistValidatorSet {
+			return err
+		}
+		if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+			return admin.ErrUnauthorizedTransaction
+		}
+	} else {
+		if err := vs.CheckAttestations(tx.AttestationHash(loader.ChainID()), tx.Attestations); err != nil {
+			return err
+		}
 	}
 	if tx.Amount.Less(amount.COIN.DivC(10)) {
 		return types.ErrDustAmount
@@ -131,6 +157,20 @@ func (tx *TokenLeave) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"attestations":`)
+	buffer.WriteString(`[`)
+	for i, sig := range tx.Attestations {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
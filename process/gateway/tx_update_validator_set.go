@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// UpdateValidatorSet is used to register or replace the bridge validator set of the platform
+// The first validator set is registered by the admin and later sets should be attested by the current validators
+type UpdateValidatorSet struct {
+	Timestamp_   uint64
+	From_        common.Address
+	Platform     string
+	ValidatorSet *ValidatorSet
+	Attestations []common.Signature
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateValidatorSet) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateValidatorSet) From() common.Address {
+	return tx.From_
+}
+
+// AttestationHash returns the hash that current validators sign to attest the update
+func (tx *UpdateValidatorSet) AttestationHash(ChainID uint8, Seq uint64) hash.Hash256 {
+	return encoding.Hash(&validatorSetMessage{
+		ChainID:      ChainID,
+		Platform:     tx.Platform,
+		Seq:          Seq,
+		ValidatorSet: tx.ValidatorSet,
+	})
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateValidatorSet) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if tx.ValidatorSet == nil {
+		return ErrInvalidValidatorSet
+	}
+	if err := tx.ValidatorSet.Validate(); err != nil {
+		return err
+	}
+	if vs, err := sp.ValidatorSet(loader, tx.Platform); err != nil {
+		if err != ErrNotExistValidatorSet {
+			return err
+		}
+		if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+			return admin.ErrUnauthorizedTransaction
+		}
+	} else {
+		Seq := sp.ValidatorSetSeq(loader, tx.Platform)
+		if err := vs.CheckAttestations(tx.AttestationHash(loader.ChainID(), Seq), tx.Attestations); err != nil {
+			return err
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateValidatorSet) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	if err := sp.SetValidatorSet(ctw, tx.Platform, tx.ValidatorSet); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateValidatorSet) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(tx.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"validator_set":`)
+	if bs, err := tx.ValidatorSet.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"attestations":`)
+	buffer.WriteString(`[`)
+	for i, sig := range tx.Attestations {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
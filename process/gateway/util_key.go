@@ -13,6 +13,9 @@ var (
 	tagPlatform      = []byte{3, 0}
 	tagPlatformIndex = []byte{3, 1}
 	tagPlatformCount = []byte{3, 2}
+	tagValidatorSet  = []byte{4, 0}
+	tagValidatorSeq  = []byte{4, 1}
+	tagDailyInflow   = []byte{4, 2}
 )
 
 func toERC20TXIDKey(Platform string, h hash.Hash256) []byte {
@@ -51,3 +54,24 @@ func toPolicyKey(Platform string) []byte {
 	copy(bs[2:], []byte(Platform))
 	return bs
 }
+
+func toValidatorSetKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagValidatorSet)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
+
+func toValidatorSeqKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagValidatorSeq)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
+
+func toDailyInflowKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagDailyInflow)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// ValidatorSet is the bridge validators of the platform
+// Deposits and set updates of the platform should be attested by Threshold validators
+// DailyCap limits the deposit amount of a day and zero means no limit
+type ValidatorSet struct {
+	PublicHashes []common.PublicHash
+	Threshold    uint8
+	DailyCap     *amount.Amount
+}
+
+// Validate checks that the validator set is well formed
+func (vs *ValidatorSet) Validate() error {
+	if len(vs.PublicHashes) == 0 || len(vs.PublicHashes) > 255 {
+		return ErrInvalidValidatorSet
+	}
+	if vs.Threshold == 0 || int(vs.Threshold) > len(vs.PublicHashes) {
+		return ErrInvalidValidatorSet
+	}
+	KeyMap := map[common.PublicHash]bool{}
+	for _, pubhash := range vs.PublicHashes {
+		if KeyMap[pubhash] {
+			return ErrInvalidValidatorSet
+		}
+		KeyMap[pubhash] = true
+	}
+	if vs.DailyCap == nil || vs.DailyCap.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidValidatorSet
+	}
+	return nil
+}
+
+// CheckAttestations checks that signatures of the hash are made by distinct validators which are not less than the threshold
+func (vs *ValidatorSet) CheckAttestations(h hash.Hash256, sigs []common.Signature) error {
+	KeyMap := map[common.PublicHash]bool{}
+	for _, pubhash := range vs.PublicHashes {
+		KeyMap[pubhash] = true
+	}
+	sigMap := map[common.PublicHash]bool{}
+	for _, sig := range sigs {
+		pubkey, err := common.RecoverPubkey(h, sig)
+		if err != nil {
+			return err
+		}
+		pubhash := common.NewPublicHash(pubkey)
+		if !KeyMap[pubhash] {
+			return ErrInvalidAttestation
+		}
+		if sigMap[pubhash] {
+			return ErrDuplicatedAttestation
+		}
+		sigMap[pubhash] = true
+	}
+	if len(sigMap) < int(vs.Threshold) {
+		return ErrInsufficientAttestation
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (vs *ValidatorSet) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"public_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range vs.PublicHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"threshold":`)
+	if bs, err := json.Marshal(vs.Threshold); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"daily_cap":`)
+	if bs, err := vs.DailyCap.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// tokenInMessage is the message that validators sign to attest a deposit
+type tokenInMessage struct {
+	ChainID     uint8
+	Platform    string
+	ERC20TXID   hash.Hash256
+	ERC20From   ERC20Address
+	ToAddresses []common.Address
+	Amounts     []*amount.Amount
+}
+
+// tokenLeaveMessage is the message that validators sign to attest a leave
+type tokenLeaveMessage struct {
+	ChainID   uint8
+	Platform  string
+	CoinTXID  string
+	CoinFrom  common.Address
+	ERC20TXID hash.Hash256
+	ERC20To   ERC20Address
+	Amount    *amount.Amount
+}
+
+// validatorSetMessage is the message that current validators sign to update the validator set
+type validatorSetMessage struct {
+	ChainID      uint8
+	Platform     string
+	Seq          uint64
+	ValidatorSet *ValidatorSet
+}
@@ -0,0 +1,82 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// MaxURISize is the maximum size of the metadata uri of an asset
+const MaxURISize = 256
+
+// Asset is a non-fungible asset of the collection
+// ContentHash is the hash of the content and URI locates the metadata of the asset
+// Approved can transfer the asset and it is the zero address when there is no approval
+type Asset struct {
+	ID           string
+	CollectionID string
+	Owner        common.Address
+	ContentHash  hash.Hash256
+	URI          string
+	Approved     common.Address
+}
+
+// IsApproved returns the address can transfer the asset or not
+func (as *Asset) IsApproved(addr common.Address) bool {
+	return as.Owner == addr || (as.Approved != common.Address{} && as.Approved == addr)
+}
+
+// MarshalJSON is a marshaler function
+func (as *Asset) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"id":`)
+	if bs, err := json.Marshal(as.ID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(as.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := as.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"content_hash":`)
+	if bs, err := as.ContentHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(as.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"approved":`)
+	if as.Approved != (common.Address{}) {
+		if bs, err := as.Approved.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	} else {
+		buffer.WriteString(`null`)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,16 @@
+package nft
+
+import "errors"
+
+// consensus errors
+var (
+	ErrInvalidCollectionName = errors.New("invalid collection name")
+	ErrInvalidRoyalty        = errors.New("invalid royalty")
+	ErrInvalidURI            = errors.New("invalid uri")
+	ErrNotExistCollection    = errors.New("not exist collection")
+	ErrNotExistAsset         = errors.New("not exist asset")
+	ErrNotCollectionOwner    = errors.New("not collection owner")
+	ErrNotAssetOwner         = errors.New("not asset owner")
+	ErrNotApproved           = errors.New("not approved")
+	ErrSameOwner             = errors.New("same owner")
+)
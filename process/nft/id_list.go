@@ -0,0 +1,62 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+)
+
+// idList is a list of ids that is stored by the count, items and positions of ids
+// Removing an id moves the last id to the position of it
+type idList struct {
+	get       func(key []byte) []byte
+	set       func(key []byte, value []byte)
+	countKey  []byte
+	itemKey   func(index uint32) []byte
+	numberKey func(ID string) []byte
+}
+
+func (l *idList) count() uint32 {
+	if bs := l.get(l.countKey); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+func (l *idList) items() []string {
+	Count := l.count()
+	IDs := make([]string, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		IDs = append(IDs, string(l.get(l.itemKey(i))))
+	}
+	return IDs
+}
+
+func (l *idList) add(ID string) {
+	Count := l.count()
+	l.set(l.itemKey(Count), []byte(ID))
+	l.set(l.numberKey(ID), binutil.LittleEndian.Uint32ToBytes(Count))
+	l.set(l.countKey, binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+func (l *idList) remove(ID string) {
+	Count := l.count()
+	bs := l.get(l.numberKey(ID))
+	if Count == 0 || len(bs) == 0 {
+		return
+	}
+	num := binutil.LittleEndian.Uint32(bs)
+	if num >= Count || string(l.get(l.itemKey(num))) != ID {
+		return
+	}
+	if num != Count-1 {
+		LastID := string(l.get(l.itemKey(Count - 1)))
+		l.set(l.itemKey(num), []byte(LastID))
+		l.set(l.numberKey(LastID), binutil.LittleEndian.Uint32ToBytes(num))
+	}
+	l.set(l.itemKey(Count-1), nil)
+	l.set(l.numberKey(ID), nil)
+	if Count == 1 {
+		l.set(l.countKey, nil)
+	} else {
+		l.set(l.countKey, binutil.LittleEndian.Uint32ToBytes(Count-1))
+	}
+}
@@ -0,0 +1,145 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// NFT manages collections and non-fungible assets of accounts of the chain
+type NFT struct {
+	*types.ProcessBase
+	pid   uint8
+	pm    types.ProcessManager
+	cn    types.Provider
+	vault *vault.Vault
+}
+
+// NewNFT returns a NFT
+func NewNFT(pid uint8) *NFT {
+	p := &NFT{
+		pid: pid,
+	}
+	return p
+}
+
+// ID returns the id of the process
+func (p *NFT) ID() uint8 {
+	return p.pid
+}
+
+// Name returns the name of the process
+func (p *NFT) Name() string {
+	return "fleta.nft"
+}
+
+// Version returns the version of the process
+func (p *NFT) Version() string {
+	return "0.0.1"
+}
+
+// Init initializes the process
+func (p *NFT) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	p.pm = pm
+	p.cn = cn
+
+	if vp, err := pm.ProcessByName("fleta.vault"); err != nil {
+		return err
+	} else if v, is := vp.(*vault.Vault); !is {
+		return types.ErrInvalidProcess
+	} else {
+		p.vault = v
+	}
+
+	reg.RegisterTransaction(1, &CreateCollection{})
+	reg.RegisterTransaction(2, &MintAsset{})
+	reg.RegisterTransaction(3, &TransferAsset{})
+	reg.RegisterTransaction(4, &ApproveAsset{})
+	reg.RegisterTransaction(5, &BurnAsset{})
+	reg.RegisterEvent(1, &CollectionCreatedEvent{})
+	reg.RegisterEvent(2, &AssetMintedEvent{})
+	reg.RegisterEvent(3, &AssetTransferredEvent{})
+	reg.RegisterEvent(4, &AssetApprovedEvent{})
+	reg.RegisterEvent(5, &AssetBurnedEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("nft")
+		if err != nil {
+			return err
+		}
+		s.Set("collection", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			CollectionID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Collection(loader, CollectionID)
+		})
+		s.Set("asset", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			AssetID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Asset(loader, AssetID)
+		})
+		s.Set("assetsByCollection", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			CollectionID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.AssetsByCollection(loader, CollectionID)
+		})
+		s.Set("assetsByOwner", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.AssetsByOwner(loader, addr)
+		})
+	}
+	return nil
+}
+
+// OnLoadChain called when the chain loaded
+func (p *NFT) OnLoadChain(loader types.LoaderWrapper) error {
+	return nil
+}
+
+// BeforeExecuteTransactions called before processes transactions of the block
+func (p *NFT) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// AfterExecuteTransactions called after processes transactions of the block
+func (p *NFT) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// OnSaveData called when the context of the block saved
+func (p *NFT) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
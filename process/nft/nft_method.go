@@ -0,0 +1,152 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// Collection returns the collection of the id
+func (p *NFT) Collection(loader types.Loader, ID string) (*Collection, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toCollectionKey(ID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistCollection
+	}
+	c := &Collection{}
+	if err := encoding.Unmarshal(bs, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *NFT) setCollection(ctw *types.ContextWrapper, c *Collection) error {
+	if bs, err := encoding.Marshal(c); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toCollectionKey(c.ID), bs)
+	}
+	return nil
+}
+
+// Asset returns the asset of the id
+func (p *NFT) Asset(loader types.Loader, ID string) (*Asset, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toAssetKey(ID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistAsset
+	}
+	as := &Asset{}
+	if err := encoding.Unmarshal(bs, &as); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+func (p *NFT) setAsset(ctw *types.ContextWrapper, as *Asset) error {
+	if bs, err := encoding.Marshal(as); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toAssetKey(as.ID), bs)
+	}
+	return nil
+}
+
+// AssetsByCollection returns assets of the collection
+func (p *NFT) AssetsByCollection(loader types.Loader, ID string) ([]*Asset, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if _, err := p.Collection(lw, ID); err != nil {
+		return nil, err
+	}
+	return p.assets(lw, collectionList(lw, nil, ID).items())
+}
+
+// AssetsByOwner returns assets of the account of the address
+func (p *NFT) AssetsByOwner(loader types.Loader, addr common.Address) ([]*Asset, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return p.assets(lw, ownedList(lw, nil, addr).items())
+}
+
+func (p *NFT) assets(lw types.LoaderWrapper, IDs []string) ([]*Asset, error) {
+	list := make([]*Asset, 0, len(IDs))
+	for _, ID := range IDs {
+		as, err := p.Asset(lw, ID)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, as)
+	}
+	return list, nil
+}
+
+// AddAsset adds the asset to the collection and the owner of it
+func (p *NFT) AddAsset(ctw *types.ContextWrapper, as *Asset) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := p.setAsset(ctw, as); err != nil {
+		return err
+	}
+	collectionList(ctw, ctw, as.CollectionID).add(as.ID)
+	ownedList(ctw, ctw, as.Owner).add(as.ID)
+	return nil
+}
+
+// MoveAsset moves the asset to the new owner and clears the approval
+func (p *NFT) MoveAsset(ctw *types.ContextWrapper, as *Asset, To common.Address) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	ownedList(ctw, ctw, as.Owner).remove(as.ID)
+	as.Owner = To
+	as.Approved = common.Address{}
+	if err := p.setAsset(ctw, as); err != nil {
+		return err
+	}
+	ownedList(ctw, ctw, as.Owner).add(as.ID)
+	return nil
+}
+
+// RemoveAsset removes the asset from the collection and the owner of it
+func (p *NFT) RemoveAsset(ctw *types.ContextWrapper, as *Asset) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	collectionList(ctw, ctw, as.CollectionID).remove(as.ID)
+	ownedList(ctw, ctw, as.Owner).remove(as.ID)
+	ctw.SetProcessData(toAssetKey(as.ID), nil)
+	return nil
+}
+
+func collectionList(lw types.LoaderWrapper, ctw *types.ContextWrapper, ID string) *idList {
+	l := &idList{
+		get:      lw.ProcessData,
+		countKey: toCollectionCountKey(ID),
+		itemKey: func(index uint32) []byte {
+			return toCollectionItemKey(ID, index)
+		},
+		numberKey: toCollectionNumberKey,
+	}
+	if ctw != nil {
+		l.set = ctw.SetProcessData
+	}
+	return l
+}
+
+func ownedList(lw types.LoaderWrapper, ctw *types.ContextWrapper, addr common.Address) *idList {
+	l := &idList{
+		get: func(key []byte) []byte {
+			return lw.AccountData(addr, key)
+		},
+		countKey:  tagOwnedCount,
+		itemKey:   toOwnedItemKey,
+		numberKey: toOwnedNumberKey,
+	}
+	if ctw != nil {
+		l.set = func(key []byte, value []byte) {
+			ctw.SetAccountData(addr, key, value)
+		}
+	}
+	return l
+}
@@ -0,0 +1,124 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ApproveAsset allows the approved account to transfer the asset of the sender
+// The zero address revokes the approval
+type ApproveAsset struct {
+	Timestamp_ uint64
+	From_      common.Address
+	AssetID    string
+	Approved   common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ApproveAsset) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ApproveAsset) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *ApproveAsset) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *ApproveAsset) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	as, err := sp.Asset(loader, tx.AssetID)
+	if err != nil {
+		return err
+	}
+	if tx.From() != as.Owner {
+		return ErrNotAssetOwner
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ApproveAsset) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		as, err := sp.Asset(ctw, tx.AssetID)
+		if err != nil {
+			return err
+		}
+		if tx.From() != as.Owner {
+			return ErrNotAssetOwner
+		}
+		as.Approved = tx.Approved
+		if err := sp.setAsset(ctw, as); err != nil {
+			return err
+		}
+		ev := &AssetApprovedEvent{
+			Height_:  ctw.TargetHeight(),
+			Index_:   index,
+			AssetID:  as.ID,
+			Owner:    as.Owner,
+			Approved: as.Approved,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ApproveAsset) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"asset_id":`)
+	if bs, err := json.Marshal(tx.AssetID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"approved":`)
+	if bs, err := tx.Approved.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
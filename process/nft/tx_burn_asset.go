@@ -0,0 +1,114 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// BurnAsset burns the asset of the sender
+type BurnAsset struct {
+	Timestamp_ uint64
+	From_      common.Address
+	AssetID    string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *BurnAsset) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *BurnAsset) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *BurnAsset) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *BurnAsset) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	as, err := sp.Asset(loader, tx.AssetID)
+	if err != nil {
+		return err
+	}
+	if tx.From() != as.Owner {
+		return ErrNotAssetOwner
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *BurnAsset) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		as, err := sp.Asset(ctw, tx.AssetID)
+		if err != nil {
+			return err
+		}
+		if tx.From() != as.Owner {
+			return ErrNotAssetOwner
+		}
+		if err := sp.RemoveAsset(ctw, as); err != nil {
+			return err
+		}
+		ev := &AssetBurnedEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			AssetID:      as.ID,
+			CollectionID: as.CollectionID,
+			Owner:        as.Owner,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *BurnAsset) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"asset_id":`)
+	if bs, err := json.Marshal(tx.AssetID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,122 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// CreateCollection creates the collection that is owned by the sender
+// The id of the collection is the id of the transaction
+type CreateCollection struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Name       string
+	Royalty    *amount.Amount
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *CreateCollection) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *CreateCollection) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *CreateCollection) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *CreateCollection) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	if len(tx.Name) == 0 || len(tx.Name) > 64 {
+		return ErrInvalidCollectionName
+	}
+	if tx.Royalty == nil || tx.Royalty.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidRoyalty
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *CreateCollection) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		c := &Collection{
+			ID:      types.TransactionID(ctw.TargetHeight(), index),
+			Name:    tx.Name,
+			Owner:   tx.From(),
+			Royalty: tx.Royalty,
+		}
+		if err := sp.setCollection(ctw, c); err != nil {
+			return err
+		}
+		ev := &CollectionCreatedEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			CollectionID: c.ID,
+			Owner:        c.Owner,
+			Name:         c.Name,
+			Royalty:      c.Royalty,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *CreateCollection) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"royalty":`)
+	if bs, err := tx.Royalty.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,157 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// MintAsset mints the asset of the collection to the recipient by the owner of the collection
+// The id of the asset is the id of the transaction
+type MintAsset struct {
+	Timestamp_   uint64
+	From_        common.Address
+	CollectionID string
+	To           common.Address
+	ContentHash  hash.Hash256
+	URI          string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *MintAsset) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *MintAsset) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *MintAsset) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *MintAsset) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	c, err := sp.Collection(loader, tx.CollectionID)
+	if err != nil {
+		return err
+	}
+	if tx.From() != c.Owner {
+		return ErrNotCollectionOwner
+	}
+	if len(tx.URI) > MaxURISize {
+		return ErrInvalidURI
+	}
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *MintAsset) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		c, err := sp.Collection(ctw, tx.CollectionID)
+		if err != nil {
+			return err
+		}
+		if tx.From() != c.Owner {
+			return ErrNotCollectionOwner
+		}
+		as := &Asset{
+			ID:           types.TransactionID(ctw.TargetHeight(), index),
+			CollectionID: c.ID,
+			Owner:        tx.To,
+			ContentHash:  tx.ContentHash,
+			URI:          tx.URI,
+		}
+		if err := sp.AddAsset(ctw, as); err != nil {
+			return err
+		}
+		ev := &AssetMintedEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			AssetID:      as.ID,
+			CollectionID: as.CollectionID,
+			To:           as.Owner,
+			ContentHash:  as.ContentHash,
+			URI:          as.URI,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *MintAsset) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(tx.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"content_hash":`)
+	if bs, err := tx.ContentHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(tx.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,163 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// TransferAsset transfers the asset to the recipient by the owner or the approved account of the asset
+// The sender pays the royalty of the collection to the owner of the collection
+type TransferAsset struct {
+	Timestamp_ uint64
+	From_      common.Address
+	AssetID    string
+	To         common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *TransferAsset) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *TransferAsset) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *TransferAsset) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *TransferAsset) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	as, err := sp.Asset(loader, tx.AssetID)
+	if err != nil {
+		return err
+	}
+	if !as.IsApproved(tx.From()) {
+		return ErrNotApproved
+	}
+	if as.Owner == tx.To {
+		return ErrSameOwner
+	}
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+	c, err := sp.Collection(loader, as.CollectionID)
+	if err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayableWith(p, loader, tx, tx.Royalty(c)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Royalty returns the royalty of the transfer
+// The owner of the collection does not pay the royalty
+func (tx *TransferAsset) Royalty(c *Collection) *amount.Amount {
+	if tx.From() == c.Owner {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return c.Royalty
+}
+
+// Execute updates the context by the transaction
+func (tx *TransferAsset) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		as, err := sp.Asset(ctw, tx.AssetID)
+		if err != nil {
+			return err
+		}
+		if !as.IsApproved(tx.From()) {
+			return ErrNotApproved
+		}
+		if as.Owner == tx.To {
+			return ErrSameOwner
+		}
+		c, err := sp.Collection(ctw, as.CollectionID)
+		if err != nil {
+			return err
+		}
+		Royalty := tx.Royalty(c)
+		if !Royalty.IsZero() {
+			if err := sp.vault.SubBalance(ctw, tx.From(), Royalty); err != nil {
+				return err
+			}
+			if err := sp.vault.AddBalance(ctw, c.Owner, Royalty); err != nil {
+				return err
+			}
+		}
+		From := as.Owner
+		if err := sp.MoveAsset(ctw, as, tx.To); err != nil {
+			return err
+		}
+		ev := &AssetTransferredEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			AssetID:      as.ID,
+			CollectionID: as.CollectionID,
+			From:         From,
+			To:           tx.To,
+			Royalty:      Royalty,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *TransferAsset) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"asset_id":`)
+	if bs, err := json.Marshal(tx.AssetID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
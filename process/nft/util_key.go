@@ -0,0 +1,67 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+)
+
+// tags
+var (
+	tagCollection       = []byte{1, 0}
+	tagCollectionCount  = []byte{1, 1}
+	tagCollectionItem   = []byte{1, 2}
+	tagCollectionNumber = []byte{1, 3}
+	tagAsset            = []byte{2, 0}
+	tagOwnedCount       = []byte{3, 0}
+	tagOwnedItem        = []byte{3, 1}
+	tagOwnedNumber      = []byte{3, 2}
+)
+
+func toCollectionKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagCollection)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
+
+func toCollectionCountKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagCollectionCount)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
+
+func toCollectionItemKey(ID string, index uint32) []byte {
+	bs := make([]byte, 6+len(ID))
+	copy(bs, tagCollectionItem)
+	copy(bs[2:], []byte(ID))
+	binutil.BigEndian.PutUint32(bs[2+len(ID):], index)
+	return bs
+}
+
+func toCollectionNumberKey(AssetID string) []byte {
+	bs := make([]byte, 2+len(AssetID))
+	copy(bs, tagCollectionNumber)
+	copy(bs[2:], []byte(AssetID))
+	return bs
+}
+
+func toAssetKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagAsset)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
+
+func toOwnedItemKey(index uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagOwnedItem)
+	binutil.BigEndian.PutUint32(bs[2:], index)
+	return bs
+}
+
+func toOwnedNumberKey(AssetID string) []byte {
+	bs := make([]byte, 2+len(AssetID))
+	copy(bs, tagOwnedNumber)
+	copy(bs[2:], []byte(AssetID))
+	return bs
+}
@@ -14,4 +14,7 @@ var (
 	ErrExistSubscribe         = errors.New("exist subscribe")
 	ErrNotExistSubscribe      = errors.New("not exist subscribe")
 	ErrInvalidBillingAmount   = errors.New("invalid billing amount")
+	ErrNotExistSubscription   = errors.New("not exist subscription")
+	ErrInvalidPeriod          = errors.New("invalid period")
+	ErrInvalidTopicOwner      = errors.New("invalid topic owner")
 )
@@ -1,12 +1,14 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
 )
 
-// Payment manages balance of accounts of the chain
+// Payment manages payments and subscriptions of topics that are owned by merchants
 type Payment struct {
 	*types.ProcessBase
 	pid   uint8
@@ -66,6 +68,64 @@ func (p *Payment) Init(reg *types.Register, pm types.ProcessManager, cn types.Pr
 	reg.RegisterTransaction(5, &Subscribe{})
 	reg.RegisterTransaction(6, &Unsubscribe{})
 	reg.RegisterTransaction(7, &Billing{})
+	reg.RegisterTransaction(8, &TransferTopic{})
+
+	reg.RegisterEvent(1, &SubscriptionBilledEvent{})
+	reg.RegisterEvent(2, &SubscriptionBillingFailedEvent{})
+	reg.RegisterEvent(3, &SubscriptionSuspendedEvent{})
+	reg.RegisterEvent(4, &TopicTransferredEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("payment")
+		if err != nil {
+			return err
+		}
+		s.Set("topic", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TopicName, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.TopicInfo(loader, Topic(TopicName))
+		})
+		s.Set("subscribers", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TopicName, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Subscribers(loader, Topic(TopicName))
+		})
+		s.Set("subscription", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TopicName, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Subscription(loader, Topic(TopicName), addr)
+		})
+	}
 	return nil
 }
 
@@ -94,6 +154,9 @@ func (p *Payment) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Payment) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	if err := p.chargeSubscriptions(ctw); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1,8 +1,11 @@
 package payment
 
 import (
+	"bytes"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/encoding"
 )
@@ -43,6 +46,39 @@ func (p *Payment) GetTopicName(loader types.Loader, topic uint64) (string, error
 	}
 }
 
+// TopicOwner returns the owner of the topic
+// Topics that are added before owners are owned by the admin
+func (p *Payment) TopicOwner(loader types.Loader, topic uint64) (common.Address, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if _, err := p.GetTopicName(lw, topic); err != nil {
+		return common.Address{}, err
+	}
+	if bs := lw.ProcessData(toTopicOwnerKey(topic)); len(bs) > 0 {
+		var addr common.Address
+		copy(addr[:], bs)
+		return addr, nil
+	}
+	return p.admin.AdminAddress(lw, p.Name()), nil
+}
+
+// TopicInfo returns the name and the owner of the topic
+func (p *Payment) TopicInfo(loader types.Loader, topic uint64) (*TopicInfo, error) {
+	Name, err := p.GetTopicName(loader, topic)
+	if err != nil {
+		return nil, err
+	}
+	Owner, err := p.TopicOwner(loader, topic)
+	if err != nil {
+		return nil, err
+	}
+	return &TopicInfo{
+		Topic: topic,
+		Name:  Name,
+		Owner: Owner,
+	}, nil
+}
+
 func (p *Payment) addTopic(ctw *types.ContextWrapper, topic uint64, Name string) error {
 	if bs := ctw.ProcessData(toTopicKey(topic)); len(bs) > 0 {
 		return ErrExistTopic
@@ -51,6 +87,33 @@ func (p *Payment) addTopic(ctw *types.ContextWrapper, topic uint64, Name string)
 	return nil
 }
 
+// addTopicWithOwner stores the owner when it is not the admin or when the topic had an owner before it is removed
+func (p *Payment) addTopicWithOwner(ctw *types.ContextWrapper, topic uint64, Name string, Owner common.Address) error {
+	if err := p.addTopic(ctw, topic, Name); err != nil {
+		return err
+	}
+	if Owner != p.admin.AdminAddress(ctw, p.Name()) || len(ctw.ProcessData(toTopicOwnerKey(topic))) > 0 {
+		p.setTopicOwner(ctw, topic, Owner)
+	}
+	return nil
+}
+
+func (p *Payment) setTopicOwner(ctw *types.ContextWrapper, topic uint64, Owner common.Address) {
+	ctw.SetProcessData(toTopicOwnerKey(topic), Owner[:])
+}
+
+// topicSigner returns the owner of the topic or the admin when the topic is removed
+func (p *Payment) topicSigner(loader types.Loader, topic uint64) (common.Address, error) {
+	Owner, err := p.TopicOwner(loader, topic)
+	if err != nil {
+		if err != ErrNotExistTopic {
+			return common.Address{}, err
+		}
+		return p.admin.AdminAddress(loader, p.Name()), nil
+	}
+	return Owner, nil
+}
+
 func (p *Payment) removeTopic(ctw *types.ContextWrapper, topic uint64) {
 	ctw.SetProcessData(toTopicKey(topic), nil)
 }
@@ -75,3 +138,283 @@ func (p *Payment) addSubscribe(ctw *types.ContextWrapper, topic uint64, addr com
 func (p *Payment) removeSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
 	ctw.SetAccountData(addr, toTopicKey(topic), nil)
 }
+
+// Subscription returns the recurring subscription of the address
+func (p *Payment) Subscription(loader types.Loader, topic uint64, addr common.Address) (*Subscription, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	var s *Subscription
+	if bs := lw.AccountData(addr, toSubscriptionKey(topic)); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &s); err != nil {
+			return nil, err
+		}
+	}
+	if s == nil {
+		return nil, ErrNotExistSubscription
+	}
+	return s, nil
+}
+
+// Subscribers returns recurring subscriptions of the topic
+func (p *Payment) Subscribers(loader types.Loader, topic uint64) ([]*Subscription, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.subscriberCount(lw, topic)
+	list := make([]*Subscription, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		var addr common.Address
+		copy(addr[:], lw.ProcessData(toSubscriberKey(topic, i)))
+		s, err := p.Subscription(lw, topic, addr)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func (p *Payment) setSubscription(ctw *types.ContextWrapper, s *Subscription) error {
+	body, err := encoding.Marshal(s)
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(s.Address, toSubscriptionKey(s.Topic), body)
+	return nil
+}
+
+func (p *Payment) removeSubscription(ctw *types.ContextWrapper, topic uint64, addr common.Address) error {
+	if _, err := p.Subscription(ctw, topic, addr); err != nil {
+		if err == ErrNotExistSubscription {
+			return nil
+		}
+		return err
+	}
+	ctw.SetAccountData(addr, toSubscriptionKey(topic), nil)
+	p.removeSubscriber(ctw, topic, addr)
+	return nil
+}
+
+func (p *Payment) subscriberCount(lw types.LoaderWrapper, topic uint64) uint32 {
+	if bs := lw.ProcessData(toSubscriberCountKey(topic)); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+func (p *Payment) addSubscriber(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	Count := p.subscriberCount(ctw, topic)
+	ctw.SetProcessData(toSubscriberKey(topic, Count), addr[:])
+	ctw.SetProcessData(toSubscriberNumberKey(topic, addr), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+// removeSubscriber moves the last subscriber to the position of the removed one
+func (p *Payment) removeSubscriber(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	Count := p.subscriberCount(ctw, topic)
+	bs := ctw.ProcessData(toSubscriberNumberKey(topic, addr))
+	if Count == 0 || len(bs) == 0 {
+		return
+	}
+	num := binutil.LittleEndian.Uint32(bs)
+	if num >= Count || !bytes.Equal(ctw.ProcessData(toSubscriberKey(topic, num)), addr[:]) {
+		return
+	}
+	if num != Count-1 {
+		last := ctw.ProcessData(toSubscriberKey(topic, Count-1))
+		var lastAddr common.Address
+		copy(lastAddr[:], last)
+		ctw.SetProcessData(toSubscriberKey(topic, num), last)
+		ctw.SetProcessData(toSubscriberNumberKey(topic, lastAddr), binutil.LittleEndian.Uint32ToBytes(num))
+	}
+	ctw.SetProcessData(toSubscriberKey(topic, Count-1), nil)
+	ctw.SetProcessData(toSubscriberNumberKey(topic, addr), nil)
+	if Count == 1 {
+		ctw.SetProcessData(toSubscriberCountKey(topic), nil)
+	} else {
+		ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Count-1))
+	}
+}
+
+func (p *Payment) dues(lw types.LoaderWrapper, Height uint32) ([]*dueEntry, error) {
+	list := []*dueEntry{}
+	if bs := lw.ProcessData(toDueKey(Height)); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (p *Payment) setDues(ctw *types.ContextWrapper, Height uint32, list []*dueEntry) error {
+	if len(list) == 0 {
+		ctw.SetProcessData(toDueKey(Height), nil)
+		return nil
+	}
+	body, err := encoding.Marshal(list)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toDueKey(Height), body)
+	return nil
+}
+
+func (p *Payment) addDue(ctw *types.ContextWrapper, Height uint32, topic uint64, addr common.Address) error {
+	list, err := p.dues(ctw, Height)
+	if err != nil {
+		return err
+	}
+	list = append(list, &dueEntry{
+		Topic:   topic,
+		Address: addr,
+		Height:  Height,
+	})
+	return p.setDues(ctw, Height, list)
+}
+
+// chargeSubscriptions bills subscriptions that are due at the target height
+// Entries over MaxBillingCountPerBlock are carried to the front of the next block
+func (p *Payment) chargeSubscriptions(ctw *types.ContextWrapper) error {
+	TargetHeight := ctw.TargetHeight()
+	list, err := p.dues(ctw, TargetHeight)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	if err := p.setDues(ctw, TargetHeight, nil); err != nil {
+		return err
+	}
+	if len(list) > MaxBillingCountPerBlock {
+		next, err := p.dues(ctw, TargetHeight+1)
+		if err != nil {
+			return err
+		}
+		carried := append(list[MaxBillingCountPerBlock:], next...)
+		if err := p.setDues(ctw, TargetHeight+1, carried); err != nil {
+			return err
+		}
+		list = list[:MaxBillingCountPerBlock]
+	}
+
+	scheduled := map[uint32][]*dueEntry{}
+	heights := []uint32{}
+	for _, d := range list {
+		s, err := p.Subscription(ctw, d.Topic, d.Address)
+		if err != nil {
+			if err == ErrNotExistSubscription {
+				continue
+			}
+			return err
+		}
+		if s.Suspended || s.NextDueHeight != d.Height {
+			continue
+		}
+		Owner, err := p.TopicOwner(ctw, s.Topic)
+		if err != nil {
+			if err != ErrNotExistTopic {
+				return err
+			}
+			if err := p.suspendSubscription(ctw, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if has, err := ctw.HasAccount(s.Address); err != nil {
+			return err
+		} else if !has {
+			if err := p.suspendSubscription(ctw, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if has, err := ctw.HasAccount(Owner); err != nil {
+			return err
+		} else if !has {
+			if err := p.suspendSubscription(ctw, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if TargetHeight+s.Period <= TargetHeight {
+			if err := p.suspendSubscription(ctw, s); err != nil {
+				return err
+			}
+			continue
+		}
+		s.NextDueHeight = TargetHeight + s.Period
+		if p.vault.Balance(ctw, s.Address).Less(s.Amount) {
+			s.MissedCount++
+			if err := ctw.EmitEvent(&SubscriptionBillingFailedEvent{
+				Height_:       TargetHeight,
+				Index_:        65535,
+				Topic:         s.Topic,
+				Subscriber:    s.Address,
+				Amount:        s.Amount,
+				MissedCount:   s.MissedCount,
+				NextDueHeight: s.NextDueHeight,
+			}); err != nil {
+				return err
+			}
+			if s.MissedCount >= MaxMissedCount {
+				if err := p.suspendSubscription(ctw, s); err != nil {
+					return err
+				}
+				continue
+			}
+		} else {
+			if err := p.vault.SubBalance(ctw, s.Address, s.Amount); err != nil {
+				return err
+			}
+			if err := p.vault.AddBalance(ctw, Owner, s.Amount); err != nil {
+				return err
+			}
+			s.MissedCount = 0
+			if err := ctw.EmitEvent(&SubscriptionBilledEvent{
+				Height_:       TargetHeight,
+				Index_:        65535,
+				Topic:         s.Topic,
+				Subscriber:    s.Address,
+				Amount:        s.Amount,
+				NextDueHeight: s.NextDueHeight,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := p.setSubscription(ctw, s); err != nil {
+			return err
+		}
+		if _, has := scheduled[s.NextDueHeight]; !has {
+			heights = append(heights, s.NextDueHeight)
+		}
+		scheduled[s.NextDueHeight] = append(scheduled[s.NextDueHeight], &dueEntry{
+			Topic:   s.Topic,
+			Address: s.Address,
+			Height:  s.NextDueHeight,
+		})
+	}
+	for _, h := range heights {
+		dues, err := p.dues(ctw, h)
+		if err != nil {
+			return err
+		}
+		if err := p.setDues(ctw, h, append(dues, scheduled[h]...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Payment) suspendSubscription(ctw *types.ContextWrapper, s *Subscription) error {
+	s.Suspended = true
+	if err := p.setSubscription(ctw, s); err != nil {
+		return err
+	}
+	return ctw.EmitEvent(&SubscriptionSuspendedEvent{
+		Height_:    ctw.TargetHeight(),
+		Index_:     65535,
+		Topic:      s.Topic,
+		Subscriber: s.Address,
+	})
+}
@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+)
+
+// MaxMissedCount is the number of consecutive failed billings that suspends the subscription
+const MaxMissedCount = 3
+
+// MaxSubscriptionPeriod is the maximum period of the subscription (about a year)
+const MaxSubscriptionPeriod = 63072000
+
+// MaxBillingCountPerBlock is the maximum number of subscriptions that are billed in a block
+// Subscriptions over the limit are carried to the next block
+const MaxBillingCountPerBlock = 1000
+
+// subscription statuses
+const (
+	StatusActive    = "active"
+	StatusGrace     = "grace"
+	StatusSuspended = "suspended"
+)
+
+// Subscription is the recurring subscription that is billed automatically at every Period blocks
+// A failed billing is retried at the next period while the subscription is in the grace status
+// and the subscription is suspended after MaxMissedCount consecutive failures
+type Subscription struct {
+	Topic         uint64
+	Address       common.Address
+	Amount        *amount.Amount
+	Period        uint32
+	NextDueHeight uint32
+	MissedCount   uint8
+	Suspended     bool
+}
+
+// Status returns the status of the subscription
+func (s *Subscription) Status() string {
+	if s.Suspended {
+		return StatusSuspended
+	} else if s.MissedCount > 0 {
+		return StatusGrace
+	}
+	return StatusActive
+}
+
+// MarshalJSON is a marshaler function
+func (s *Subscription) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(s.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"address":`)
+	if bs, err := s.Address.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := s.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(s.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"next_due_height":`)
+	if s.Suspended {
+		buffer.WriteString(`null`)
+	} else if bs, err := json.Marshal(s.NextDueHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"missed_count":`)
+	if bs, err := json.Marshal(s.MissedCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"status":`)
+	if bs, err := json.Marshal(s.Status()); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// dueEntry is the subscription that is billed at the due height
+// Height is the due height of the subscription when the entry is carried to later blocks
+type dueEntry struct {
+	Topic   uint64
+	Address common.Address
+	Height  uint32
+}
@@ -0,0 +1,299 @@
+package payment
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+type testLoader struct {
+	accs map[common.Address]types.Account
+}
+
+func (l *testLoader) ChainID() uint8                           { return 1 }
+func (l *testLoader) Name() string                             { return "test" }
+func (l *testLoader) Version() uint16                          { return 1 }
+func (l *testLoader) TargetHeight() uint32                     { return 1 }
+func (l *testLoader) LastHash() hash.Hash256                   { return hash.Hash256{} }
+func (l *testLoader) LastTimestamp() uint64                    { return 0 }
+func (l *testLoader) HasAccountName(Name string) (bool, error) { return false, nil }
+func (l *testLoader) HasUTXO(id uint64) (bool, error)          { return false, nil }
+func (l *testLoader) UTXO(id uint64) (*types.UTXO, error)      { return nil, types.ErrNotExistUTXO }
+func (l *testLoader) IsUsedTimeSlot(slot uint32, key string) bool {
+	return false
+}
+func (l *testLoader) AccountData(addr common.Address, pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) ProcessData(pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) AddressByName(Name string) (common.Address, error) {
+	return common.Address{}, types.ErrNotExistAccount
+}
+func (l *testLoader) Account(addr common.Address) (types.Account, error) {
+	if acc, has := l.accs[addr]; has {
+		return acc, nil
+	}
+	return nil, types.ErrNotExistAccount
+}
+func (l *testLoader) HasAccount(addr common.Address) (bool, error) {
+	_, has := l.accs[addr]
+	return has, nil
+}
+
+type testChain struct {
+	t     *testing.T
+	ctx   *types.Context
+	p     *Payment
+	v     *vault.Vault
+	addrs []common.Address
+	index uint16
+}
+
+// newTestChain prepares the payment process of which the admin is addrs[0] and the topic "test" is owned by the admin
+func newTestChain(t *testing.T, n int) *testChain {
+	ld := &testLoader{accs: map[common.Address]types.Account{}}
+	addrs := []common.Address{}
+	for i := 0; i < n; i++ {
+		addr := common.NewAddress(0, uint16(i), 0)
+		ld.accs[addr] = &vault.SingleAccount{Address_: addr, KeyHash: common.PublicHash{byte(i + 1)}}
+		addrs = append(addrs, addr)
+	}
+	ctx := types.NewContext(ld)
+	ad := admin.NewAdmin(1)
+	v := vault.NewVault(2)
+	p := NewPayment(5)
+	p.admin = ad
+	p.vault = v
+	if err := ad.InitAdmin(types.NewContextWrapper(1, ctx), map[string]common.Address{p.Name(): addrs[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InitPolicy(types.NewContextWrapper(2, ctx), &vault.Policy{AccountCreationAmount: amount.NewCoinAmount(10, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.InitTopics(types.NewContextWrapper(5, ctx), []string{"test"}); err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{t: t, ctx: ctx, p: p, v: v, addrs: addrs}
+}
+
+func (tc *testChain) ctw() *types.ContextWrapper {
+	return types.NewContextWrapper(tc.p.ID(), tc.ctx)
+}
+
+func (tc *testChain) addBalance(i int, Coin uint64) {
+	if err := tc.v.AddBalance(tc.ctw(), tc.addrs[i], amount.NewCoinAmount(Coin, 0)); err != nil {
+		tc.t.Fatal(err)
+	}
+}
+
+func (tc *testChain) balance(i int) *amount.Amount {
+	return tc.v.Balance(tc.ctx, tc.addrs[i])
+}
+
+func (tc *testChain) run(tx types.Transaction, signers ...int) error {
+	pubhashes := []common.PublicHash{}
+	for _, i := range signers {
+		pubhashes = append(pubhashes, common.PublicHash{byte(i + 1)})
+	}
+	if err := tx.Validate(tc.p, types.NewLoaderWrapper(tc.p.ID(), tc.ctx), pubhashes); err != nil {
+		return err
+	}
+	sn := tc.ctx.Snapshot()
+	tc.index++
+	if err := tx.Execute(tc.p, tc.ctw(), tc.index); err != nil {
+		tc.ctx.Revert(sn)
+		return err
+	}
+	tc.ctx.Commit(sn)
+	return nil
+}
+
+// endBlock bills subscriptions of the current block and moves to the next block
+func (tc *testChain) endBlock() []types.Event {
+	if err := tc.p.AfterExecuteTransactions(nil, tc.ctw()); err != nil {
+		tc.t.Fatal(err)
+	}
+	events := tc.ctx.Top().Events
+	tc.ctx = tc.ctx.NextContext(hash.Hash256{}, 0)
+	tc.index = 0
+	return events
+}
+
+func (tc *testChain) subscription(i int) *Subscription {
+	s, err := tc.p.Subscription(tc.ctx, Topic("test"), tc.addrs[i])
+	if err != nil {
+		tc.t.Fatal(err)
+	}
+	return s
+}
+
+func TestSubscriptionBilling(t *testing.T) {
+	tc := newTestChain(t, 2)
+	tc.addBalance(1, 25)
+
+	if err := tc.run(&Subscribe{From_: tc.addrs[1], Topic: Topic("test"), Amount: amount.NewCoinAmount(10, 0), Period: MaxSubscriptionPeriod + 1}, 0, 1); err != ErrInvalidPeriod {
+		t.Fatal("invalid period is allowed", err)
+	}
+	if err := tc.run(&Subscribe{From_: tc.addrs[1], Topic: Topic("test"), Amount: amount.NewCoinAmount(10, 0), Period: 2}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	// the first billing is at the subscribed height
+	events := tc.endBlock()
+	if len(events) != 1 {
+		t.Fatal("invalid event count", len(events))
+	} else if ev, is := events[0].(*SubscriptionBilledEvent); !is || ev.NextDueHeight != 3 {
+		t.Fatal("invalid billed event", events[0])
+	}
+	if !tc.balance(0).Equal(amount.NewCoinAmount(10, 0)) || !tc.balance(1).Equal(amount.NewCoinAmount(15, 0)) {
+		t.Fatal("invalid balance", tc.balance(0), tc.balance(1))
+	}
+	if events := tc.endBlock(); len(events) != 0 {
+		t.Fatal("billed before the due height", events)
+	}
+	tc.endBlock()
+	if !tc.balance(0).Equal(amount.NewCoinAmount(20, 0)) || !tc.balance(1).Equal(amount.NewCoinAmount(5, 0)) {
+		t.Fatal("invalid balance", tc.balance(0), tc.balance(1))
+	}
+	if s := tc.subscription(1); s.NextDueHeight != 5 || s.Status() != StatusActive {
+		t.Fatal("invalid subscription", s.NextDueHeight, s.Status())
+	}
+}
+
+func TestSubscriptionGraceAndSuspension(t *testing.T) {
+	tc := newTestChain(t, 2)
+	tc.addBalance(1, 5)
+
+	if err := tc.run(&Subscribe{From_: tc.addrs[1], Topic: Topic("test"), Amount: amount.NewCoinAmount(10, 0), Period: 1}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	events := tc.endBlock()
+	if len(events) != 1 {
+		t.Fatal("invalid event count", len(events))
+	} else if ev, is := events[0].(*SubscriptionBillingFailedEvent); !is || ev.MissedCount != 1 {
+		t.Fatal("invalid billing failed event", events[0])
+	}
+	if s := tc.subscription(1); s.Status() != StatusGrace {
+		t.Fatal("subscription is not in the grace status", s.Status())
+	}
+
+	// a billing in the grace status resets the missed count
+	tc.addBalance(1, 5)
+	tc.endBlock()
+	if s := tc.subscription(1); s.Status() != StatusActive || s.MissedCount != 0 {
+		t.Fatal("subscription is not active", s.Status())
+	}
+
+	for i := 0; i < MaxMissedCount-1; i++ {
+		tc.endBlock()
+	}
+	events = tc.endBlock()
+	if len(events) != 2 {
+		t.Fatal("invalid event count", len(events))
+	} else if _, is := events[1].(*SubscriptionSuspendedEvent); !is {
+		t.Fatal("invalid suspended event", events[1])
+	}
+	if s := tc.subscription(1); s.Status() != StatusSuspended {
+		t.Fatal("subscription is not suspended", s.Status())
+	}
+	tc.addBalance(1, 10)
+	if events := tc.endBlock(); len(events) != 0 {
+		t.Fatal("suspended subscription is billed", events)
+	}
+	if !tc.balance(1).Equal(amount.NewCoinAmount(10, 0)) {
+		t.Fatal("invalid balance", tc.balance(1))
+	}
+}
+
+func TestSubscriptionUnsubscribe(t *testing.T) {
+	tc := newTestChain(t, 4)
+	for i := 1; i < 4; i++ {
+		tc.addBalance(i, 100)
+		if err := tc.run(&Subscribe{From_: tc.addrs[i], Topic: Topic("test"), Amount: amount.NewCoinAmount(10, 0), Period: 1}, 0, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tc.endBlock()
+
+	if err := tc.run(&Unsubscribe{From_: tc.addrs[1], Topic: Topic("test")}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tc.p.Subscription(tc.ctx, Topic("test"), tc.addrs[1]); err != ErrNotExistSubscription {
+		t.Fatal("subscription is not removed", err)
+	}
+	list, err := tc.p.Subscribers(tc.ctx, Topic("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Address != tc.addrs[3] || list[1].Address != tc.addrs[2] {
+		t.Fatal("invalid subscribers", list)
+	}
+	if events := tc.endBlock(); len(events) != 2 {
+		t.Fatal("invalid event count", len(events))
+	}
+	if !tc.balance(1).Equal(amount.NewCoinAmount(90, 0)) {
+		t.Fatal("unsubscribed account is billed", tc.balance(1))
+	}
+
+	// subscribing again in the block of unsubscribing starts a new schedule
+	if err := tc.run(&Unsubscribe{From_: tc.addrs[2], Topic: Topic("test")}, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.run(&Subscribe{From_: tc.addrs[2], Topic: Topic("test"), Amount: amount.NewCoinAmount(20, 0), Period: 3}, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if s := tc.subscription(2); !s.Amount.Equal(amount.NewCoinAmount(20, 0)) || s.Period != 3 {
+		t.Fatal("invalid subscription", s)
+	}
+	tc.endBlock()
+	if !tc.balance(2).Equal(amount.NewCoinAmount(60, 0)) {
+		t.Fatal("invalid balance", tc.balance(2))
+	}
+}
+
+func TestSubscriptionCarryOver(t *testing.T) {
+	tc := newTestChain(t, 1)
+	ctw := tc.ctw()
+	Count := MaxBillingCountPerBlock + 10
+	dues := []*dueEntry{}
+	for i := 0; i < Count; i++ {
+		addr := common.NewAddress(1, uint16(i), 0)
+		if err := tc.ctx.CreateAccount(&vault.SingleAccount{Address_: addr, Name_: fmt.Sprintf("test%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+		if err := tc.v.AddBalance(ctw, addr, amount.NewCoinAmount(10, 0)); err != nil {
+			t.Fatal(err)
+		}
+		s := &Subscription{
+			Topic:         Topic("test"),
+			Address:       addr,
+			Amount:        amount.NewCoinAmount(1, 0),
+			Period:        100,
+			NextDueHeight: tc.ctx.TargetHeight(),
+		}
+		if err := tc.p.setSubscription(ctw, s); err != nil {
+			t.Fatal(err)
+		}
+		tc.p.addSubscriber(ctw, s.Topic, s.Address)
+		dues = append(dues, &dueEntry{Topic: s.Topic, Address: s.Address, Height: s.NextDueHeight})
+	}
+	if err := tc.p.setDues(ctw, tc.ctx.TargetHeight(), dues); err != nil {
+		t.Fatal(err)
+	}
+	if events := tc.endBlock(); len(events) != MaxBillingCountPerBlock {
+		t.Fatal("invalid event count", len(events))
+	}
+	if events := tc.endBlock(); len(events) != 10 {
+		t.Fatal("invalid event count", len(events))
+	}
+	if !tc.balance(0).Equal(amount.NewCoinAmount(uint64(Count), 0)) {
+		t.Fatal("invalid balance", tc.balance(0))
+	}
+}
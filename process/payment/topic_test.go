@@ -0,0 +1,43 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func TestTopicTransferKeepsRequests(t *testing.T) {
+	tc := newTestChain(t, 4)
+	tc.addBalance(3, 10)
+
+	topic := Topic("shop")
+	if err := tc.run(&AddTopic{From_: tc.addrs[1], Topic: topic, TopicName: "shop"}, 1); err == nil {
+		t.Fatal("topic is added without the admin")
+	}
+	if err := tc.run(&AddTopic{From_: tc.addrs[1], Topic: topic, TopicName: "shop"}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.run(&RequestPayment{From_: tc.addrs[1], Topic: topic, To: tc.addrs[3], Amount: amount.NewCoinAmount(3, 0)}, 1); err != nil {
+		t.Fatal(err)
+	}
+	TXID := types.TransactionID(tc.ctx.TargetHeight(), tc.index)
+	if err := tc.run(&TransferTopic{From_: tc.addrs[1], Topic: topic, To: tc.addrs[2]}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if Owner, err := tc.p.TopicOwner(tc.ctx, topic); err != nil {
+		t.Fatal(err)
+	} else if Owner != tc.addrs[2] {
+		t.Fatal("topic is not transferred", Owner)
+	}
+	if err := tc.run(&RequestPayment{From_: tc.addrs[1], Topic: topic, To: tc.addrs[3], Amount: amount.NewCoinAmount(3, 0)}, 1); err == nil {
+		t.Fatal("previous owner requests the payment")
+	}
+
+	if err := tc.run(&ResponsePayment{From_: tc.addrs[3], TXID: TXID, Amount: amount.NewCoinAmount(3, 0), IsAccept: true}, 3); err != nil {
+		t.Fatal(err)
+	}
+	if !tc.balance(1).Equal(amount.NewCoinAmount(3, 0)) || !tc.balance(2).IsZero() {
+		t.Fatal("invalid balance", tc.balance(1), tc.balance(2))
+	}
+}
@@ -10,6 +10,7 @@ import (
 )
 
 // AddTopic is a AddTopic
+// The sender owns the topic and a topic of other than the admin is co-signed by the admin
 type AddTopic struct {
 	Timestamp_ uint64
 	From_      common.Address
@@ -31,18 +32,44 @@ func (tx *AddTopic) From() common.Address {
 func (tx *AddTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if tx.Topic != Topic(tx.TopicName) {
 		return ErrInvalidTopicName
 	}
+	if _, err := sp.GetTopicName(loader, tx.Topic); err == nil {
+		return ErrExistTopic
+	} else if err != ErrNotExistTopic {
+		return err
+	}
+
+	adminAddr := sp.admin.AdminAddress(loader, p.Name())
+	if tx.From() == adminAddr {
+		fromAcc, err := loader.Account(tx.From())
+		if err != nil {
+			return err
+		}
+		if err := fromAcc.Validate(loader, signers); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if len(signers) <= 1 {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	adminAcc, err := loader.Account(adminAddr)
+	if err != nil {
+		return err
+	}
+	if err := adminAcc.Validate(loader, signers[:1]); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
 		return err
 	}
-	if err := fromAcc.Validate(loader, signers); err != nil {
+	if err := fromAcc.Validate(loader, signers[1:]); err != nil {
 		return err
 	}
 	return nil
@@ -52,7 +79,9 @@ func (tx *AddTopic) Validate(p types.Process, loader types.LoaderWrapper, signer
 func (tx *AddTopic) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Payment)
 
-	sp.addTopic(ctw, tx.Topic, tx.TopicName)
+	if err := sp.addTopicWithOwner(ctw, tx.Topic, tx.TopicName, tx.From()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -34,15 +34,14 @@ func (tx *Billing) From() common.Address {
 func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if len(tx.Content) > 255 {
 		return ErrExceedContentSize
 	}
 
-	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
+	if Owner, err := sp.TopicOwner(loader, tx.Topic); err != nil {
 		return err
+	} else if tx.From() != Owner {
+		return admin.ErrUnauthorizedTransaction
 	}
 
 	if has, err := loader.HasAccount(tx.To); err != nil {
@@ -76,7 +75,7 @@ func (tx *Billing) Execute(p types.Process, ctw *types.ContextWrapper, index uin
 	if err := sp.vault.SubBalance(ctw, tx.To, tx.Amount); err != nil {
 		return err
 	}
-	if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
+	if err := sp.vault.AddBalance(ctw, tx.From(), tx.Amount); err != nil {
 		return err
 	}
 	return nil
@@ -10,6 +10,7 @@ import (
 )
 
 // RemoveTopic is a RemoveTopic
+// The admin delists any topic and the owner removes the own topic
 type RemoveTopic struct {
 	Timestamp_ uint64
 	From_      common.Address
@@ -32,7 +33,11 @@ func (tx *RemoveTopic) Validate(p types.Process, loader types.LoaderWrapper, sig
 	sp := p.(*Payment)
 
 	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
+		if Owner, err := sp.TopicOwner(loader, tx.Topic); err != nil {
+			return err
+		} else if tx.From() != Owner {
+			return admin.ErrUnauthorizedTransaction
+		}
 	}
 	if tx.Topic != Topic(tx.TopicName) {
 		return ErrInvalidTopicName
@@ -34,9 +34,6 @@ func (tx *RequestPayment) From() common.Address {
 func (tx *RequestPayment) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if tx.Amount.Less(amount.COIN.DivC(10)) {
 		return types.ErrDustAmount
 	}
@@ -44,8 +41,10 @@ func (tx *RequestPayment) Validate(p types.Process, loader types.LoaderWrapper,
 		return ErrExceedContentSize
 	}
 
-	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
+	if Owner, err := sp.TopicOwner(loader, tx.Topic); err != nil {
 		return err
+	} else if tx.From() != Owner {
+		return admin.ErrUnauthorizedTransaction
 	}
 
 	if has, err := loader.HasAccount(tx.To); err != nil {
@@ -7,11 +7,11 @@ import (
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/core/types"
-	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/vault"
 )
 
 // ResponsePayment is a ResponsePayment
+// The payment is sent to the owner of the topic at the height of the request even if the topic is transferred after it
 type ResponsePayment struct {
 	Timestamp_ uint64
 	From_      common.Address
@@ -38,13 +38,12 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 		return types.ErrDustAmount
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
 	req, err := sp.getRequestPayment(loader, tx.TXID)
 	if err != nil {
 		return err
 	}
-	if req.From() != adminAddr {
-		return admin.ErrUnauthorizedTransaction
+	if _, err := sp.GetTopicName(loader, req.Topic); err != nil {
+		return err
 	}
 	if req.To != tx.From() {
 		return ErrInvalidRequestPayment
@@ -52,11 +51,8 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 	if !req.Amount.Equal(tx.Amount) {
 		return ErrInvalidPaymentAmount
 	}
-	if _, err := sp.GetTopicName(loader, req.Topic); err != nil {
-		return err
-	}
 
-	if has, err := loader.HasAccount(adminAddr); err != nil {
+	if has, err := loader.HasAccount(req.From()); err != nil {
 		return err
 	} else if !has {
 		return types.ErrNotExistAccount
@@ -84,10 +80,14 @@ func (tx *ResponsePayment) Execute(p types.Process, ctw *types.ContextWrapper, i
 	sp := p.(*Payment)
 
 	if tx.IsAccept {
+		req, err := sp.getRequestPayment(ctw, tx.TXID)
+		if err != nil {
+			return err
+		}
 		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
 			return err
 		}
-		if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
+		if err := sp.vault.AddBalance(ctw, req.From(), tx.Amount); err != nil {
 			return err
 		}
 	}
@@ -11,11 +11,13 @@ import (
 )
 
 // Subscribe is a Subscribe
+// A subscription that has the period is billed the amount automatically from the subscribed height at every period
 type Subscribe struct {
 	Timestamp_ uint64
 	From_      common.Address
 	Topic      uint64
 	Amount     *amount.Amount
+	Period     uint32 `msgpack:",omitempty"`
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -35,6 +37,12 @@ func (tx *Subscribe) Validate(p types.Process, loader types.LoaderWrapper, signe
 	if !tx.Amount.IsZero() && tx.Amount.Less(amount.COIN.DivC(10)) {
 		return types.ErrDustAmount
 	}
+	if tx.Period > MaxSubscriptionPeriod {
+		return ErrInvalidPeriod
+	}
+	if tx.Period > 0 && tx.Amount.IsZero() {
+		return ErrInvalidPaymentAmount
+	}
 
 	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
 		return err
@@ -44,12 +52,15 @@ func (tx *Subscribe) Validate(p types.Process, loader types.LoaderWrapper, signe
 		return admin.ErrUnauthorizedTransaction
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
-	adminAcc, err := loader.Account(adminAddr)
+	Owner, err := sp.topicSigner(loader, tx.Topic)
+	if err != nil {
+		return err
+	}
+	ownerAcc, err := loader.Account(Owner)
 	if err != nil {
 		return err
 	}
-	if err := adminAcc.Validate(loader, signers[:1]); err != nil {
+	if err := ownerAcc.Validate(loader, signers[:1]); err != nil {
 		return err
 	}
 
@@ -70,6 +81,22 @@ func (tx *Subscribe) Execute(p types.Process, ctw *types.ContextWrapper, index u
 	if err := sp.addSubscribe(ctw, tx.Topic, tx.From(), tx.Amount); err != nil {
 		return err
 	}
+	if tx.Period > 0 {
+		s := &Subscription{
+			Topic:         tx.Topic,
+			Address:       tx.From(),
+			Amount:        tx.Amount,
+			Period:        tx.Period,
+			NextDueHeight: ctw.TargetHeight(),
+		}
+		if err := sp.setSubscription(ctw, s); err != nil {
+			return err
+		}
+		sp.addSubscriber(ctw, s.Topic, s.Address)
+		if err := sp.addDue(ctw, s.NextDueHeight, s.Topic, s.Address); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -104,6 +131,13 @@ func (tx *Subscribe) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(tx.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
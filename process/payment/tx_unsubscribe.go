@@ -34,12 +34,15 @@ func (tx *Unsubscribe) Validate(p types.Process, loader types.LoaderWrapper, sig
 		return admin.ErrUnauthorizedTransaction
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
-	adminAcc, err := loader.Account(adminAddr)
+	Owner, err := sp.topicSigner(loader, tx.Topic)
 	if err != nil {
 		return err
 	}
-	if err := adminAcc.Validate(loader, signers[:1]); err != nil {
+	ownerAcc, err := loader.Account(Owner)
+	if err != nil {
+		return err
+	}
+	if err := ownerAcc.Validate(loader, signers[:1]); err != nil {
 		return err
 	}
 
@@ -58,6 +61,9 @@ func (tx *Unsubscribe) Execute(p types.Process, ctw *types.ContextWrapper, index
 	sp := p.(*Payment)
 
 	sp.removeSubscribe(ctw, tx.Topic, tx.From())
+	if err := sp.removeSubscription(ctw, tx.Topic, tx.From()); err != nil {
+		return err
+	}
 	return nil
 }
 
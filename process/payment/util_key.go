@@ -1,13 +1,20 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/binutil"
 )
 
 // tags
 var (
-	tagRequestPayment = []byte{1, 0}
-	tagTopic          = []byte{2, 0}
+	tagRequestPayment   = []byte{1, 0}
+	tagTopic            = []byte{2, 0}
+	tagTopicOwner       = []byte{2, 1}
+	tagSubscription     = []byte{3, 0}
+	tagSubscriberCount  = []byte{3, 1}
+	tagSubscriber       = []byte{3, 2}
+	tagSubscriberNumber = []byte{3, 3}
+	tagDue              = []byte{3, 4}
 )
 
 func toRequestPaymentKey(TXID string) []byte {
@@ -23,3 +30,47 @@ func toTopicKey(topic uint64) []byte {
 	binutil.BigEndian.PutUint64(bs[2:], topic)
 	return bs
 }
+
+func toTopicOwnerKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagTopicOwner)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toSubscriptionKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagSubscription)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toSubscriberCountKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagSubscriberCount)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toSubscriberKey(topic uint64, index uint32) []byte {
+	bs := make([]byte, 14)
+	copy(bs, tagSubscriber)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	binutil.BigEndian.PutUint32(bs[10:], index)
+	return bs
+}
+
+func toSubscriberNumberKey(topic uint64, addr common.Address) []byte {
+	bs := make([]byte, 10+common.AddressSize)
+	copy(bs, tagSubscriberNumber)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	copy(bs[10:], addr[:])
+	return bs
+}
+
+func toDueKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagDue)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
@@ -0,0 +1,19 @@
+package token
+
+import "errors"
+
+// consensus errors
+var (
+	ErrInvalidSymbol         = errors.New("invalid symbol")
+	ErrInvalidTokenName      = errors.New("invalid token name")
+	ErrInvalidDecimals       = errors.New("invalid decimals")
+	ErrInvalidAmount         = errors.New("invalid amount")
+	ErrExistToken            = errors.New("exist token")
+	ErrNotExistToken         = errors.New("not exist token")
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	ErrNotMintable           = errors.New("not mintable")
+	ErrNotMintAuthority      = errors.New("not mint authority")
+	ErrNotTokenOwner         = errors.New("not token owner")
+	ErrFrozenAccount         = errors.New("frozen account")
+)
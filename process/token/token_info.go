@@ -0,0 +1,95 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+)
+
+// MaxDecimals is the maximum decimals of a token that is same with the precision of the amount
+const MaxDecimals = amount.FractionalCount
+
+// TokenInfo is the metadata of the token
+// Amounts of the token use the precision of the amount and they are multiples of the smallest unit of the Decimals
+// MintAuthority can mint the token and it is the zero address when the supply is fixed
+type TokenInfo struct {
+	Symbol        string
+	Name          string
+	Decimals      uint8
+	Supply        *amount.Amount
+	Owner         common.Address
+	MintAuthority common.Address
+}
+
+// IsMintable returns the token has the mint authority or not
+func (ti *TokenInfo) IsMintable() bool {
+	return ti.MintAuthority != common.Address{}
+}
+
+// CheckAmount checks that the amount is not minus and fits the decimals of the token
+func (ti *TokenInfo) CheckAmount(am *amount.Amount) error {
+	if am == nil || am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidAmount
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(MaxDecimals-int(ti.Decimals))), nil)
+	if new(big.Int).Mod(am.Int, unit).Sign() != 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (ti *TokenInfo) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(ti.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(ti.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"decimals":`)
+	if bs, err := json.Marshal(ti.Decimals); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"supply":`)
+	if bs, err := ti.Supply.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := ti.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"mint_authority":`)
+	if ti.IsMintable() {
+		if bs, err := ti.MintAuthority.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	} else {
+		buffer.WriteString(`null`)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,159 @@
+package token
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// CheckSymbol checks that the symbol has 2 ~ 10 upper case alphabets or digits
+func CheckSymbol(Symbol string) error {
+	if len(Symbol) < 2 || len(Symbol) > 10 {
+		return ErrInvalidSymbol
+	}
+	for _, c := range Symbol {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return ErrInvalidSymbol
+		}
+	}
+	if Symbol == "FLETA" {
+		return ErrInvalidSymbol
+	}
+	return nil
+}
+
+// TokenInfo returns the token info of the symbol
+func (p *Token) TokenInfo(loader types.Loader, Symbol string) (*TokenInfo, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toTokenKey(Symbol))
+	if len(bs) == 0 {
+		return nil, ErrNotExistToken
+	}
+	ti := &TokenInfo{}
+	if err := encoding.Unmarshal(bs, &ti); err != nil {
+		return nil, err
+	}
+	return ti, nil
+}
+
+// TokenInfos returns token infos in the issued order
+func (p *Token) TokenInfos(loader types.Loader) ([]*TokenInfo, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	var Count uint32
+	if bs := lw.ProcessData(tagTokenCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	list := make([]*TokenInfo, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		ti, err := p.TokenInfo(lw, string(lw.ProcessData(toTokenIndexKey(i))))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ti)
+	}
+	return list, nil
+}
+
+func (p *Token) addTokenInfo(ctw *types.ContextWrapper, ti *TokenInfo) error {
+	if bs := ctw.ProcessData(toTokenKey(ti.Symbol)); len(bs) > 0 {
+		return ErrExistToken
+	}
+	if err := p.setTokenInfo(ctw, ti); err != nil {
+		return err
+	}
+	var Count uint32
+	if bs := ctw.ProcessData(tagTokenCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	ctw.SetProcessData(toTokenIndexKey(Count), []byte(ti.Symbol))
+	ctw.SetProcessData(tagTokenCount, binutil.LittleEndian.Uint32ToBytes(Count+1))
+	return nil
+}
+
+func (p *Token) setTokenInfo(ctw *types.ContextWrapper, ti *TokenInfo) error {
+	if bs, err := encoding.Marshal(ti); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toTokenKey(ti.Symbol), bs)
+	}
+	return nil
+}
+
+// Balance returns the token balance of the account of the address
+func (p *Token) Balance(loader types.Loader, Symbol string, addr common.Address) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(addr, toBalanceKey(Symbol)); len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
+// AddBalance adds the token balance to the account of the address
+func (p *Token) AddBalance(ctw *types.ContextWrapper, Symbol string, addr common.Address, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidAmount
+	}
+	ctw.SetAccountData(addr, toBalanceKey(Symbol), p.Balance(ctw, Symbol, addr).Add(am).Bytes())
+	return nil
+}
+
+// SubBalance subtracts the token balance from the account of the address
+func (p *Token) SubBalance(ctw *types.ContextWrapper, Symbol string, addr common.Address, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidAmount
+	}
+	sum := p.Balance(ctw, Symbol, addr)
+	if sum.Less(am) {
+		return ErrInsufficientBalance
+	}
+	ctw.SetAccountData(addr, toBalanceKey(Symbol), sum.Sub(am).Bytes())
+	return nil
+}
+
+// Allowance returns the amount that the spender can transfer from the account of the owner
+func (p *Token) Allowance(loader types.Loader, Symbol string, Owner common.Address, Spender common.Address) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(Owner, toAllowanceKey(Symbol, Spender)); len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
+// SetAllowance updates the amount that the spender can transfer from the account of the owner
+func (p *Token) SetAllowance(ctw *types.ContextWrapper, Symbol string, Owner common.Address, Spender common.Address, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidAmount
+	}
+	ctw.SetAccountData(Owner, toAllowanceKey(Symbol, Spender), am.Bytes())
+	return nil
+}
+
+// IsFrozen returns the token of the account of the address is frozen or not
+func (p *Token) IsFrozen(loader types.Loader, Symbol string, addr common.Address) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return len(lw.AccountData(addr, toFrozenKey(Symbol))) > 0
+}
+
+// SetFrozen freezes or unfreezes the token of the account of the address
+func (p *Token) SetFrozen(ctw *types.ContextWrapper, Symbol string, addr common.Address, Frozen bool) {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if Frozen {
+		ctw.SetAccountData(addr, toFrozenKey(Symbol), []byte{1})
+	} else {
+		ctw.SetAccountData(addr, toFrozenKey(Symbol), nil)
+	}
+}
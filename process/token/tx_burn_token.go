@@ -0,0 +1,127 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// BurnToken burns the token of the sender and decreases the supply
+type BurnToken struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Symbol     string
+	Amount     *amount.Amount
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *BurnToken) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *BurnToken) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *BurnToken) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Token)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *BurnToken) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Token)
+
+	ti, err := sp.TokenInfo(loader, tx.Symbol)
+	if err != nil {
+		return err
+	}
+	if err := ti.CheckAmount(tx.Amount); err != nil {
+		return err
+	}
+	if tx.Amount.IsZero() {
+		return ErrInvalidAmount
+	}
+	if sp.IsFrozen(loader, tx.Symbol, tx.From()) {
+		return ErrFrozenAccount
+	}
+	if sp.Balance(loader, tx.Symbol, tx.From()).Less(tx.Amount) {
+		return ErrInsufficientBalance
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *BurnToken) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Token)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		if sp.IsFrozen(ctw, tx.Symbol, tx.From()) {
+			return ErrFrozenAccount
+		}
+		ti, err := sp.TokenInfo(ctw, tx.Symbol)
+		if err != nil {
+			return err
+		}
+		if err := sp.SubBalance(ctw, tx.Symbol, tx.From(), tx.Amount); err != nil {
+			return err
+		}
+		ti.Supply = ti.Supply.Sub(tx.Amount)
+		if err := sp.setTokenInfo(ctw, ti); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *BurnToken) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(tx.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
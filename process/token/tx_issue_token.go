@@ -0,0 +1,167 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// IssueToken issues the token of the symbol and gives the supply to the issuer
+// The issuer becomes the owner of the token that can freeze accounts of the token
+type IssueToken struct {
+	Timestamp_    uint64
+	From_         common.Address
+	Symbol        string
+	Name          string
+	Decimals      uint8
+	Supply        *amount.Amount
+	MintAuthority common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *IssueToken) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *IssueToken) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *IssueToken) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Token)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// TokenInfo returns the token info that is issued by the transaction
+func (tx *IssueToken) TokenInfo() *TokenInfo {
+	return &TokenInfo{
+		Symbol:        tx.Symbol,
+		Name:          tx.Name,
+		Decimals:      tx.Decimals,
+		Supply:        tx.Supply,
+		Owner:         tx.From(),
+		MintAuthority: tx.MintAuthority,
+	}
+}
+
+// Validate validates signatures of the transaction
+func (tx *IssueToken) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Token)
+
+	if err := CheckSymbol(tx.Symbol); err != nil {
+		return err
+	}
+	if len(tx.Name) == 0 || len(tx.Name) > 64 {
+		return ErrInvalidTokenName
+	}
+	if tx.Decimals > MaxDecimals {
+		return ErrInvalidDecimals
+	}
+	ti := tx.TokenInfo()
+	if err := ti.CheckAmount(tx.Supply); err != nil {
+		return err
+	}
+	if ti.IsMintable() {
+		if has, err := loader.HasAccount(tx.MintAuthority); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+	} else if tx.Supply.IsZero() {
+		return ErrInvalidAmount
+	}
+	if _, err := sp.TokenInfo(loader, tx.Symbol); err == nil {
+		return ErrExistToken
+	} else if err != ErrNotExistToken {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *IssueToken) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Token)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		if err := sp.addTokenInfo(ctw, tx.TokenInfo()); err != nil {
+			return err
+		}
+		if err := sp.AddBalance(ctw, tx.Symbol, tx.From(), tx.Supply); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *IssueToken) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(tx.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"decimals":`)
+	if bs, err := json.Marshal(tx.Decimals); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"supply":`)
+	if bs, err := tx.Supply.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"mint_authority":`)
+	if bs, err := tx.MintAuthority.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// TransferTokenFrom transfers the token from the account of the owner to the recipient by the allowance of the sender
+type TransferTokenFrom struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Symbol     string
+	Owner      common.Address
+	To         common.Address
+	Amount     *amount.Amount
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *TransferTokenFrom) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *TransferTokenFrom) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *TransferTokenFrom) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Token)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *TransferTokenFrom) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Token)
+
+	ti, err := sp.TokenInfo(loader, tx.Symbol)
+	if err != nil {
+		return err
+	}
+	if err := ti.CheckAmount(tx.Amount); err != nil {
+		return err
+	}
+	if tx.Amount.IsZero() {
+		return ErrInvalidAmount
+	}
+	if sp.IsFrozen(loader, tx.Symbol, tx.Owner) {
+		return ErrFrozenAccount
+	}
+	if sp.Allowance(loader, tx.Symbol, tx.Owner, tx.From()).Less(tx.Amount) {
+		return ErrInsufficientAllowance
+	}
+	if sp.Balance(loader, tx.Symbol, tx.Owner).Less(tx.Amount) {
+		return ErrInsufficientBalance
+	}
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *TransferTokenFrom) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Token)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		if sp.IsFrozen(ctw, tx.Symbol, tx.Owner) {
+			return ErrFrozenAccount
+		}
+		allowance := sp.Allowance(ctw, tx.Symbol, tx.Owner, tx.From())
+		if allowance.Less(tx.Amount) {
+			return ErrInsufficientAllowance
+		}
+		if err := sp.SetAllowance(ctw, tx.Symbol, tx.Owner, tx.From(), allowance.Sub(tx.Amount)); err != nil {
+			return err
+		}
+		if err := sp.SubBalance(ctw, tx.Symbol, tx.Owner, tx.Amount); err != nil {
+			return err
+		}
+		if err := sp.AddBalance(ctw, tx.Symbol, tx.To, tx.Amount); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *TransferTokenFrom) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(tx.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := tx.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
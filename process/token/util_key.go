@@ -0,0 +1,52 @@
+package token
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+)
+
+// tags
+var (
+	tagToken      = []byte{1, 0}
+	tagTokenIndex = []byte{1, 1}
+	tagTokenCount = []byte{1, 2}
+	tagBalance    = []byte{2, 0}
+	tagAllowance  = []byte{2, 1}
+	tagFrozen     = []byte{2, 2}
+)
+
+func toTokenKey(Symbol string) []byte {
+	bs := make([]byte, 2+len(Symbol))
+	copy(bs, tagToken)
+	copy(bs[2:], []byte(Symbol))
+	return bs
+}
+
+func toTokenIndexKey(index uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagTokenIndex)
+	binutil.BigEndian.PutUint32(bs[2:], index)
+	return bs
+}
+
+func toBalanceKey(Symbol string) []byte {
+	bs := make([]byte, 2+len(Symbol))
+	copy(bs, tagBalance)
+	copy(bs[2:], []byte(Symbol))
+	return bs
+}
+
+func toAllowanceKey(Symbol string, Spender common.Address) []byte {
+	bs := make([]byte, 2+common.AddressSize+len(Symbol))
+	copy(bs, tagAllowance)
+	copy(bs[2:], Spender[:])
+	copy(bs[2+common.AddressSize:], []byte(Symbol))
+	return bs
+}
+
+func toFrozenKey(Symbol string) []byte {
+	bs := make([]byte, 2+len(Symbol))
+	copy(bs, tagFrozen)
+	copy(bs[2:], []byte(Symbol))
+	return bs
+}
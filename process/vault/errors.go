@@ -17,4 +17,20 @@ var (
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
 	ErrInvalidTagSize                   = errors.New("invalid tag size")
 	ErrInvalidDefaultFee                = errors.New("invalid default fee")
+	ErrInvalidUnlockHeight              = errors.New("invalid unlock height")
+	ErrInvalidVestingSchedule           = errors.New("invalid vesting schedule")
+	ErrInvalidTimeoutHeight             = errors.New("invalid timeout height")
+	ErrInvalidPreimage                  = errors.New("invalid preimage")
+	ErrNotExistEscrow                   = errors.New("not exist escrow")
+	ErrEscrowTimeout                    = errors.New("escrow timeout")
+	ErrEscrowNotTimeout                 = errors.New("escrow not timeout")
+	ErrNotEscrowParty                   = errors.New("not escrow party")
+	ErrInvalidOutputCount               = errors.New("invalid output count")
+	ErrInvalidRecoveryConfig            = errors.New("invalid recovery config")
+	ErrNotExistRecoveryConfig           = errors.New("not exist recovery config")
+	ErrNotExistRecovery                 = errors.New("not exist recovery")
+	ErrExistRecovery                    = errors.New("exist recovery")
+	ErrNotGuardian                      = errors.New("not guardian")
+	ErrAlreadyApprovedRecovery          = errors.New("already approved recovery")
+	ErrRecoveryNotReady                 = errors.New("recovery not ready")
 )
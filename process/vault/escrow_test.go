@@ -0,0 +1,127 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+type testLoader struct {
+	accs map[common.Address]types.Account
+}
+
+func (l *testLoader) ChainID() uint8                           { return 1 }
+func (l *testLoader) Name() string                             { return "test" }
+func (l *testLoader) Version() uint16                          { return 1 }
+func (l *testLoader) TargetHeight() uint32                     { return 1 }
+func (l *testLoader) KeyResetHeight() uint32                   { return 2 }
+func (l *testLoader) LastHash() hash.Hash256                   { return hash.Hash256{} }
+func (l *testLoader) LastTimestamp() uint64                    { return 0 }
+func (l *testLoader) HasAccountName(Name string) (bool, error) { return false, nil }
+func (l *testLoader) HasUTXO(id uint64) (bool, error)          { return false, nil }
+func (l *testLoader) UTXO(id uint64) (*types.UTXO, error)      { return nil, types.ErrNotExistUTXO }
+func (l *testLoader) IsUsedTimeSlot(slot uint32, key string) bool {
+	return false
+}
+func (l *testLoader) AccountData(addr common.Address, pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) ProcessData(pid uint8, name []byte) []byte {
+	return nil
+}
+func (l *testLoader) AddressByName(Name string) (common.Address, error) {
+	return common.Address{}, types.ErrNotExistAccount
+}
+func (l *testLoader) Account(addr common.Address) (types.Account, error) {
+	if acc, has := l.accs[addr]; has {
+		return acc, nil
+	}
+	return nil, types.ErrNotExistAccount
+}
+func (l *testLoader) HasAccount(addr common.Address) (bool, error) {
+	_, has := l.accs[addr]
+	return has, nil
+}
+
+// TestEscrowListReuse locks, releases and locks escrows again in transactions of the same block
+// before the key reset height, so the escrow list should not depend on deleting and setting the same key again
+func TestEscrowListReuse(t *testing.T) {
+	From := common.NewAddress(0, 1, 0)
+	To := common.NewAddress(0, 2, 0)
+	ctx := types.NewContext(&testLoader{accs: map[common.Address]types.Account{
+		From: &SingleAccount{Address_: From, KeyHash: common.PublicHash{1}},
+		To:   &SingleAccount{Address_: To, KeyHash: common.PublicHash{2}},
+	}})
+	p := NewVault(2)
+	ctw := types.NewContextWrapper(p.ID(), ctx)
+	nextBlock := func() {
+		if err := p.AfterExecuteTransactions(&types.Block{Header: types.Header{Height: ctx.TargetHeight()}}, ctw); err != nil {
+			t.Fatal(err)
+		}
+		ctx = ctx.NextContext(hash.Hash256{}, 0)
+		ctw = types.NewContextWrapper(p.ID(), ctx)
+	}
+	if err := p.AddBalance(ctw, From, amount.NewCoinAmount(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := func(fn func() error) {
+		sn := ctw.Snapshot()
+		if err := fn(); err != nil {
+			t.Fatal(err)
+		}
+		ctw.Commit(sn)
+	}
+	check := func(addr common.Address, IDs ...string) {
+		list, err := p.Escrows(ctw, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != len(IDs) {
+			t.Fatalf("expected %v but %v escrows", len(IDs), len(list))
+		}
+		for i, es := range list {
+			if es.ID != IDs[i] {
+				t.Fatalf("expected %v but %v", IDs[i], es.ID)
+			}
+		}
+	}
+	newEscrow := func(ID string) *Escrow {
+		return &Escrow{ID: ID, From: From, To: To, Amount: amount.NewCoinAmount(1, 0), TimeoutHeight: 10}
+	}
+
+	esA := newEscrow("a")
+	esB := newEscrow("b")
+	esC := newEscrow("c")
+	tx(func() error { return p.AddEscrow(ctw, esA) })
+	tx(func() error { return p.AddEscrow(ctw, esB) })
+	tx(func() error { return p.ReleaseEscrow(ctw, esA, To) })
+	check(To, "b")
+	tx(func() error { return p.ReleaseEscrow(ctw, esB, To) })
+	check(To)
+	tx(func() error { return p.AddEscrow(ctw, esC) })
+	check(From, "c")
+	check(To, "c")
+
+	nextBlock()
+	check(To, "c")
+	if bs := ctw.AccountData(To, toEscrowItemKey(1)); len(bs) > 0 {
+		t.Fatal("item after the count remains", string(bs))
+	}
+	if bs := ctw.ProcessData(tagEscrowCleanup); len(bs) > 0 {
+		t.Fatal("cleanup list remains")
+	}
+
+	tx(func() error { return p.ReleaseEscrow(ctw, esC, From) })
+	nextBlock()
+	check(To)
+	if bs := ctw.AccountData(To, tagEscrowCount); len(bs) > 0 {
+		t.Fatal("empty escrow list remains")
+	}
+	if bs := ctw.AccountData(To, toEscrowItemKey(0)); len(bs) > 0 {
+		t.Fatal("item of the empty escrow list remains")
+	}
+}
@@ -2,13 +2,26 @@ package vault
 
 import (
 	"bytes"
+	"encoding/json"
 
 	"github.com/fletaio/fleta/common/amount"
 )
 
+// DefaultMaxTransferOutputCount is the maximum output count of a multi transfer when the policy does not define it
+const DefaultMaxTransferOutputCount = 100
+
 // Policy defines a vault policy
 type Policy struct {
-	AccountCreationAmount *amount.Amount
+	AccountCreationAmount  *amount.Amount
+	MaxTransferOutputCount uint16 `msgpack:",omitempty"`
+}
+
+// TransferOutputLimit returns the maximum output count of a multi transfer
+func (pc *Policy) TransferOutputLimit() int {
+	if pc.MaxTransferOutputCount == 0 {
+		return DefaultMaxTransferOutputCount
+	}
+	return int(pc.MaxTransferOutputCount)
 }
 
 // MarshalJSON is a marshaler function
@@ -21,6 +34,13 @@ func (pc *Policy) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_transfer_output_count":`)
+	if bs, err := json.Marshal(pc.TransferOutputLimit()); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -0,0 +1,214 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// MaxGuardianCount is the maximum number of guardians of a recovery config
+const MaxGuardianCount = 16
+
+// MinRecoveryDelay is the minimum delay of a recovery config (about an hour) so that the owner can cancel the recovery
+const MinRecoveryDelay = 7200
+
+// RecoveryConfig defines guardians that can rotate the key of the single account when the owner loses it
+// The rotation is finalized after Delay blocks from the height that approvals reach the Threshold
+type RecoveryConfig struct {
+	Guardians []common.Address
+	Threshold uint8
+	Delay     uint32
+}
+
+// Validate validates the recovery config of the account of the address
+func (rc *RecoveryConfig) Validate(addr common.Address) error {
+	if len(rc.Guardians) == 0 || len(rc.Guardians) > MaxGuardianCount {
+		return ErrInvalidRecoveryConfig
+	}
+	if rc.Threshold == 0 || int(rc.Threshold) > len(rc.Guardians) {
+		return ErrInvalidRecoveryConfig
+	}
+	if rc.Delay < MinRecoveryDelay {
+		return ErrInvalidRecoveryConfig
+	}
+	guardianMap := map[common.Address]bool{}
+	for _, g := range rc.Guardians {
+		if g == addr || guardianMap[g] {
+			return ErrInvalidRecoveryConfig
+		}
+		guardianMap[g] = true
+	}
+	return nil
+}
+
+// IsGuardian returns the address is the guardian or not
+func (rc *RecoveryConfig) IsGuardian(addr common.Address) bool {
+	for _, g := range rc.Guardians {
+		if g == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON is a marshaler function
+func (rc *RecoveryConfig) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"guardians":[`)
+	for i, g := range rc.Guardians {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := g.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"threshold":`)
+	if bs, err := json.Marshal(rc.Threshold); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"delay":`)
+	if bs, err := json.Marshal(rc.Delay); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// Recovery is the pending key rotation of the single account
+// ReadyHeight is zero until approvals reach the threshold
+type Recovery struct {
+	KeyHash         common.PublicHash
+	InitiatedHeight uint32
+	ReadyHeight     uint32
+	Approvals       []common.Address
+}
+
+// IsApproved returns the guardian approved the recovery or not
+func (rv *Recovery) IsApproved(addr common.Address) bool {
+	for _, a := range rv.Approvals {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// FinalizableHeight returns the height from which the recovery can be finalized
+// It returns zero when approvals do not reach the threshold
+func (rv *Recovery) FinalizableHeight(rc *RecoveryConfig) uint32 {
+	if rv.ReadyHeight == 0 {
+		return 0
+	}
+	return rv.ReadyHeight + rc.Delay
+}
+
+// MarshalJSON is a marshaler function
+func (rv *Recovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"key_hash":`)
+	if bs, err := rv.KeyHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"initiated_height":`)
+	if bs, err := json.Marshal(rv.InitiatedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"ready_height":`)
+	if bs, err := json.Marshal(rv.ReadyHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"approvals":[`)
+	for i, a := range rv.Approvals {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := a.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// RecoveryStatus is the recovery config and the pending recovery of the account
+type RecoveryStatus struct {
+	Config   *RecoveryConfig
+	Recovery *Recovery
+}
+
+// MarshalJSON is a marshaler function
+func (rs *RecoveryStatus) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"config":`)
+	if rs.Config == nil {
+		buffer.WriteString(`null`)
+	} else if bs, err := rs.Config.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"recovery":`)
+	if rs.Recovery == nil {
+		buffer.WriteString(`null`)
+	} else if bs, err := rs.Recovery.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	if rs.Config != nil && rs.Recovery != nil {
+		buffer.WriteString(`,`)
+		buffer.WriteString(`"finalizable_height":`)
+		if bs, err := json.Marshal(rs.Recovery.FinalizableHeight(rs.Config)); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// checkRecovery returns the recovery config of the single account of the address
+func checkRecovery(sp *Vault, loader types.LoaderWrapper, addr common.Address) (*SingleAccount, *RecoveryConfig, error) {
+	acc, err := loader.Account(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	singleAcc, is := acc.(*SingleAccount)
+	if !is {
+		return nil, nil, types.ErrInvalidAccountType
+	}
+	rc, err := sp.RecoveryConfig(loader, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return singleAcc, rc, nil
+}
@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ApproveRecovery approves the pending recovery of the single account by a guardian
+// KeyHash should be same with the key hash of the pending recovery
+type ApproveRecovery struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Account    common.Address
+	KeyHash    common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ApproveRecovery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ApproveRecovery) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *ApproveRecovery) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *ApproveRecovery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if _, _, err := tx.check(sp, loader); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *ApproveRecovery) check(sp *Vault, loader types.LoaderWrapper) (*RecoveryConfig, *Recovery, error) {
+	_, rc, err := checkRecovery(sp, loader, tx.Account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rc.IsGuardian(tx.From()) {
+		return nil, nil, ErrNotGuardian
+	}
+	rv, err := sp.Recovery(loader, tx.Account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rv.KeyHash != tx.KeyHash {
+		return nil, nil, ErrNotExistRecovery
+	}
+	if rv.IsApproved(tx.From()) {
+		return nil, nil, ErrAlreadyApprovedRecovery
+	}
+	return rc, rv, nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ApproveRecovery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		rc, rv, err := tx.check(sp, ctw)
+		if err != nil {
+			return err
+		}
+		rv.Approvals = append(rv.Approvals, tx.From())
+		if rv.ReadyHeight == 0 && len(rv.Approvals) >= int(rc.Threshold) {
+			rv.ReadyHeight = ctw.TargetHeight()
+		}
+		if err := sp.SetRecovery(ctw, tx.Account, rv); err != nil {
+			return err
+		}
+		ev := &RecoveryApprovedEvent{
+			Height_:           ctw.TargetHeight(),
+			Index_:            index,
+			Account:           tx.Account,
+			Guardian:          tx.From(),
+			ApprovalCount:     uint8(len(rv.Approvals)),
+			FinalizableHeight: rv.FinalizableHeight(rc),
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ApproveRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"account":`)
+	if bs, err := tx.Account.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hash":`)
+	if bs, err := tx.KeyHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
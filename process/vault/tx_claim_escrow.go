@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ClaimEscrow claims the escrow to the recipient by the preimage of the hash lock before the timeout height
+type ClaimEscrow struct {
+	Timestamp_ uint64
+	From_      common.Address
+	EscrowID   string
+	Preimage   []byte
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ClaimEscrow) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ClaimEscrow) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *ClaimEscrow) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *ClaimEscrow) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	es, err := sp.Escrow(loader, tx.EscrowID)
+	if err != nil {
+		return err
+	}
+	if err := tx.check(es, loader.TargetHeight()); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *ClaimEscrow) check(es *Escrow, Height uint32) error {
+	if es.To != tx.From() {
+		return ErrNotEscrowParty
+	}
+	if Height >= es.TimeoutHeight {
+		return ErrEscrowTimeout
+	}
+	if len(tx.Preimage) > MaxPreimageSize {
+		return ErrInvalidPreimage
+	}
+	if hash.Hash(tx.Preimage) != es.HashLock {
+		return ErrInvalidPreimage
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ClaimEscrow) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		es, err := sp.Escrow(ctw, tx.EscrowID)
+		if err != nil {
+			return err
+		}
+		if err := tx.check(es, ctw.TargetHeight()); err != nil {
+			return err
+		}
+		if err := sp.ReleaseEscrow(ctw, es, es.To); err != nil {
+			return err
+		}
+		ev := &EscrowClaimedEvent{
+			Height_:  ctw.TargetHeight(),
+			Index_:   index,
+			EscrowID: es.ID,
+			To:       es.To,
+			Amount:   es.Amount,
+			Preimage: tx.Preimage,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ClaimEscrow) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"escrow_id":`)
+	if bs, err := json.Marshal(tx.EscrowID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"preimage":`)
+	if bs, err := json.Marshal(hex.EncodeToString(tx.Preimage)); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// FinalizeRecovery rotates the key of the single account to the key hash of the approved recovery after the delay
+// Any account can finalize it
+type FinalizeRecovery struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Account    common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *FinalizeRecovery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *FinalizeRecovery) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *FinalizeRecovery) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *FinalizeRecovery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if _, _, err := tx.check(sp, loader); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *FinalizeRecovery) check(sp *Vault, loader types.LoaderWrapper) (*SingleAccount, *Recovery, error) {
+	singleAcc, rc, err := checkRecovery(sp, loader, tx.Account)
+	if err != nil {
+		return nil, nil, err
+	}
+	rv, err := sp.Recovery(loader, tx.Account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rv.ReadyHeight == 0 || loader.TargetHeight() < rv.FinalizableHeight(rc) {
+		return nil, nil, ErrRecoveryNotReady
+	}
+	return singleAcc, rv, nil
+}
+
+// Execute updates the context by the transaction
+func (tx *FinalizeRecovery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		singleAcc, rv, err := tx.check(sp, ctw)
+		if err != nil {
+			return err
+		}
+		singleAcc.KeyHash = rv.KeyHash
+		if err := sp.SetRecovery(ctw, tx.Account, nil); err != nil {
+			return err
+		}
+		ev := &RecoveryFinalizedEvent{
+			Height_: ctw.TargetHeight(),
+			Index_:  index,
+			Account: tx.Account,
+			KeyHash: rv.KeyHash,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *FinalizeRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"account":`)
+	if bs, err := tx.Account.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,144 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// InitiateRecovery starts the key rotation of the single account by a guardian
+// The initiating guardian approves the recovery
+type InitiateRecovery struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Account    common.Address
+	KeyHash    common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *InitiateRecovery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *InitiateRecovery) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *InitiateRecovery) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *InitiateRecovery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if err := tx.check(sp, loader); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *InitiateRecovery) check(sp *Vault, loader types.LoaderWrapper) error {
+	_, rc, err := checkRecovery(sp, loader, tx.Account)
+	if err != nil {
+		return err
+	}
+	if !rc.IsGuardian(tx.From()) {
+		return ErrNotGuardian
+	}
+	if _, err := sp.Recovery(loader, tx.Account); err == nil {
+		return ErrExistRecovery
+	} else if err != ErrNotExistRecovery {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *InitiateRecovery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := tx.check(sp, ctw); err != nil {
+			return err
+		}
+		rc, err := sp.RecoveryConfig(ctw, tx.Account)
+		if err != nil {
+			return err
+		}
+		rv := &Recovery{
+			KeyHash:         tx.KeyHash,
+			InitiatedHeight: ctw.TargetHeight(),
+			Approvals:       []common.Address{tx.From()},
+		}
+		if len(rv.Approvals) >= int(rc.Threshold) {
+			rv.ReadyHeight = ctw.TargetHeight()
+		}
+		if err := sp.SetRecovery(ctw, tx.Account, rv); err != nil {
+			return err
+		}
+		ev := &RecoveryInitiatedEvent{
+			Height_:           ctw.TargetHeight(),
+			Index_:            index,
+			Account:           tx.Account,
+			Guardian:          tx.From(),
+			KeyHash:           tx.KeyHash,
+			FinalizableHeight: rv.FinalizableHeight(rc),
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *InitiateRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"account":`)
+	if bs, err := tx.Account.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hash":`)
+	if bs, err := tx.KeyHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
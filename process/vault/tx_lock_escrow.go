@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// LockEscrow locks the amount to the escrow by the hash lock until the timeout height
+type LockEscrow struct {
+	Timestamp_    uint64
+	From_         common.Address
+	To            common.Address
+	Amount        *amount.Amount
+	HashLock      hash.Hash256
+	TimeoutHeight uint32
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *LockEscrow) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *LockEscrow) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *LockEscrow) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *LockEscrow) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.TimeoutHeight <= loader.TargetHeight() {
+		return ErrInvalidTimeoutHeight
+	}
+	if tx.Amount.Less(amount.COIN.DivC(10)) {
+		return types.ErrDustAmount
+	}
+
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *LockEscrow) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if tx.TimeoutHeight <= ctw.TargetHeight() {
+			return ErrInvalidTimeoutHeight
+		}
+		es := &Escrow{
+			ID:            types.TransactionID(ctw.TargetHeight(), index),
+			From:          tx.From(),
+			To:            tx.To,
+			Amount:        tx.Amount,
+			HashLock:      tx.HashLock,
+			TimeoutHeight: tx.TimeoutHeight,
+		}
+		if err := sp.AddEscrow(ctw, es); err != nil {
+			return err
+		}
+		ev := &EscrowLockedEvent{
+			Height_:       ctw.TargetHeight(),
+			Index_:        index,
+			EscrowID:      es.ID,
+			From:          es.From,
+			To:            es.To,
+			Amount:        es.Amount,
+			HashLock:      es.HashLock,
+			TimeoutHeight: es.TimeoutHeight,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *LockEscrow) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"hash_lock":`)
+	if bs, err := tx.HashLock.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"timeout_height":`)
+	if bs, err := json.Marshal(tx.TimeoutHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
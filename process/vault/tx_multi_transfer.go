@@ -0,0 +1,182 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// TransferOutput is an output of the multi transfer
+type TransferOutput struct {
+	To     common.Address
+	Amount *amount.Amount
+	Tag    string
+}
+
+// MarshalJSON is a marshaler function
+func (out *TransferOutput) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"to":`)
+	if bs, err := out.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := out.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tag":`)
+	if bs, err := json.Marshal(out.Tag); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// MultiTransfer transfers amounts to outputs at once
+// All outputs are applied or nothing is applied and the fee is the default fee per output
+type MultiTransfer struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Outputs    []*TransferOutput
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *MultiTransfer) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *MultiTransfer) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *MultiTransfer) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader).MulC(int64(len(tx.Outputs)))
+}
+
+// TotalAmount returns the sum of amounts of outputs
+func (tx *MultiTransfer) TotalAmount() *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for _, out := range tx.Outputs {
+		sum = sum.Add(out.Amount)
+	}
+	return sum
+}
+
+// Validate validates signatures of the transaction
+func (tx *MultiTransfer) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	policy := &Policy{}
+	if err := encoding.Unmarshal(loader.ProcessData(tagPolicy), &policy); err != nil {
+		return err
+	}
+	if len(tx.Outputs) == 0 || len(tx.Outputs) > policy.TransferOutputLimit() {
+		return ErrInvalidOutputCount
+	}
+	for _, out := range tx.Outputs {
+		if out == nil || out.Amount == nil {
+			return ErrInvalidOutputCount
+		}
+		if len(out.Tag) > 32 {
+			return ErrInvalidTagSize
+		}
+		if out.Amount.Less(amount.COIN.DivC(10)) {
+			return types.ErrDustAmount
+		}
+		if has, err := loader.HasAccount(out.To); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.TotalAmount()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *MultiTransfer) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.TotalAmount()); err != nil {
+			return err
+		}
+		for i, out := range tx.Outputs {
+			if err := sp.AddBalance(ctw, out.To, out.Amount); err != nil {
+				return err
+			}
+			ev := &TransferOutputEvent{
+				Height_:     ctw.TargetHeight(),
+				Index_:      index,
+				From:        tx.From(),
+				OutputIndex: uint16(i),
+				To:          out.To,
+				Amount:      out.Amount,
+				Tag:         out.Tag,
+			}
+			ctw.EmitEvent(ev)
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *MultiTransfer) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"outputs":[`)
+	for i, out := range tx.Outputs {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := out.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,124 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// RefundEscrow refunds the escrow to the sender from the timeout height
+type RefundEscrow struct {
+	Timestamp_ uint64
+	From_      common.Address
+	EscrowID   string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *RefundEscrow) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *RefundEscrow) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *RefundEscrow) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *RefundEscrow) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	es, err := sp.Escrow(loader, tx.EscrowID)
+	if err != nil {
+		return err
+	}
+	if err := tx.check(es, loader.TargetHeight()); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *RefundEscrow) check(es *Escrow, Height uint32) error {
+	if es.From != tx.From() {
+		return ErrNotEscrowParty
+	}
+	if Height < es.TimeoutHeight {
+		return ErrEscrowNotTimeout
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *RefundEscrow) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		es, err := sp.Escrow(ctw, tx.EscrowID)
+		if err != nil {
+			return err
+		}
+		if err := tx.check(es, ctw.TargetHeight()); err != nil {
+			return err
+		}
+		if err := sp.ReleaseEscrow(ctw, es, es.From); err != nil {
+			return err
+		}
+		ev := &EscrowRefundedEvent{
+			Height_:  ctw.TargetHeight(),
+			Index_:   index,
+			EscrowID: es.ID,
+			From:     es.From,
+			Amount:   es.Amount,
+		}
+		ctw.EmitEvent(ev)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *RefundEscrow) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"escrow_id":`)
+	if bs, err := json.Marshal(tx.EscrowID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
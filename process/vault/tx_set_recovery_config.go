@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// SetRecoveryConfig updates guardians of the single account that can rotate the key when the owner loses it
+// A nil config removes the recovery config and it drops the pending recovery
+type SetRecoveryConfig struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Config     *RecoveryConfig
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SetRecoveryConfig) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *SetRecoveryConfig) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *SetRecoveryConfig) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *SetRecoveryConfig) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	singleAcc, is := acc.(*SingleAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+	if tx.Config != nil {
+		if err := tx.Config.Validate(tx.From()); err != nil {
+			return err
+		}
+		for _, g := range tx.Config.Guardians {
+			if has, err := loader.HasAccount(g); err != nil {
+				return err
+			} else if !has {
+				return types.ErrNotExistAccount
+			}
+		}
+	}
+	if err := singleAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SetRecoveryConfig) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if rv, err := sp.Recovery(ctw, tx.From()); err != nil {
+			if err != ErrNotExistRecovery {
+				return err
+			}
+		} else {
+			ev := &RecoveryCancelledEvent{
+				Height_: ctw.TargetHeight(),
+				Index_:  index,
+				Account: tx.From(),
+				KeyHash: rv.KeyHash,
+			}
+			ctw.EmitEvent(ev)
+		}
+		if err := sp.SetRecoveryConfig(ctw, tx.From(), tx.Config); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SetRecoveryConfig) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"config":`)
+	if tx.Config == nil {
+		buffer.WriteString(`null`)
+	} else if bs, err := tx.Config.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
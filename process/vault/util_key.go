@@ -17,6 +17,18 @@ var (
 	tagPolicy               = []byte{4, 0}
 	tagDefaultFee           = []byte{4, 1}
 	tagDefaultFeeIsZero     = []byte{4, 2}
+	tagVesting              = []byte{5, 0}
+	tagVestingCount         = []byte{5, 1}
+	tagVestingEnd           = []byte{5, 2}
+	tagVestingEndReverse    = []byte{5, 3}
+	tagVestingEndCount      = []byte{5, 4}
+	tagEscrow               = []byte{6, 0}
+	tagEscrowCount          = []byte{6, 1}
+	tagEscrowItem           = []byte{6, 2}
+	tagEscrowNumber         = []byte{6, 3}
+	tagEscrowCleanup        = []byte{6, 4}
+	tagRecoveryConfig       = []byte{7, 0}
+	tagRecovery             = []byte{7, 1}
 )
 
 func toLockedBalanceKey(height uint32, addr common.Address) []byte {
@@ -49,3 +61,54 @@ func toLockedBalanceCountKey(height uint32) []byte {
 	binutil.BigEndian.PutUint32(bs[2:], height)
 	return bs
 }
+
+func toVestingKey(index uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagVesting)
+	binutil.BigEndian.PutUint32(bs[2:], index)
+	return bs
+}
+
+func toVestingEndKey(height uint32, addr common.Address) []byte {
+	bs := make([]byte, 6+common.AddressSize)
+	copy(bs, tagVestingEnd)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	copy(bs[6:], addr[:])
+	return bs
+}
+
+func toVestingEndReverseKey(height uint32, num uint32) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagVestingEndReverse)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	binutil.BigEndian.PutUint32(bs[6:], num)
+	return bs
+}
+
+func toVestingEndCountKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagVestingEndCount)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
+
+func toEscrowKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagEscrow)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
+
+func toEscrowItemKey(index uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagEscrowItem)
+	binutil.BigEndian.PutUint32(bs[2:], index)
+	return bs
+}
+
+func toEscrowNumberKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagEscrowNumber)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
@@ -56,6 +56,25 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 	reg.RegisterTransaction(10, &UpdatePolicy{})
 	reg.RegisterTransaction(11, &ChangeSingleKey{})
 	reg.RegisterTransaction(12, &UpdateDefaultFee{})
+	reg.RegisterTransaction(13, &TransferLocked{})
+	reg.RegisterTransaction(14, &CreateVesting{})
+	reg.RegisterTransaction(15, &LockEscrow{})
+	reg.RegisterTransaction(16, &ClaimEscrow{})
+	reg.RegisterTransaction(17, &RefundEscrow{})
+	reg.RegisterTransaction(18, &MultiTransfer{})
+	reg.RegisterTransaction(19, &SetRecoveryConfig{})
+	reg.RegisterTransaction(20, &InitiateRecovery{})
+	reg.RegisterTransaction(21, &ApproveRecovery{})
+	reg.RegisterTransaction(22, &CancelRecovery{})
+	reg.RegisterTransaction(23, &FinalizeRecovery{})
+	reg.RegisterEvent(1, &EscrowLockedEvent{})
+	reg.RegisterEvent(2, &EscrowClaimedEvent{})
+	reg.RegisterEvent(3, &EscrowRefundedEvent{})
+	reg.RegisterEvent(4, &TransferOutputEvent{})
+	reg.RegisterEvent(5, &RecoveryInitiatedEvent{})
+	reg.RegisterEvent(6, &RecoveryApprovedEvent{})
+	reg.RegisterEvent(7, &RecoveryCancelledEvent{})
+	reg.RegisterEvent(8, &RecoveryFinalizedEvent{})
 
 	if vp, err := pm.ProcessByName("fleta.admin"); err != nil {
 		return err
@@ -92,6 +111,130 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 			loader := cn.NewLoaderWrapper(p.ID())
 			return p.CollectedFee(loader), nil
 		})
+		s.Set("lockedBalance", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.TotalLockedBalanceByAddress(loader, addr), nil
+		})
+		s.Set("vestingCount", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.VestingCount(loader, addr), nil
+		})
+		s.Set("vestings", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			Offset, err := arg.Uint32(1)
+			if err != nil {
+				return nil, err
+			}
+			Limit, err := arg.Uint32(2)
+			if err != nil {
+				return nil, err
+			}
+			if Limit > MaxVestingQueryLimit {
+				Limit = MaxVestingQueryLimit
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			list, err := p.Vestings(loader, addr, Offset, Limit)
+			if err != nil {
+				return nil, err
+			}
+			Height := cn.Height()
+			items := make([]*VestingStatus, 0, len(list))
+			for _, vs := range list {
+				items = append(items, &VestingStatus{
+					Schedule: vs,
+					Released: vs.Released(Height),
+				})
+			}
+			return items, nil
+		})
+		s.Set("escrow", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			EscrowID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Escrow(loader, EscrowID)
+		})
+		s.Set("escrows", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Escrows(loader, addr)
+		})
+		s.Set("recovery", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			rs := &RecoveryStatus{}
+			if rc, err := p.RecoveryConfig(loader, addr); err != nil {
+				if err != ErrNotExistRecoveryConfig {
+					return nil, err
+				}
+			} else {
+				rs.Config = rc
+			}
+			if rv, err := p.Recovery(loader, addr); err != nil {
+				if err != ErrNotExistRecovery {
+					return nil, err
+				}
+			} else {
+				rs.Recovery = rv
+			}
+			return rs, nil
+		})
 	}
 	return nil
 }
@@ -138,6 +281,11 @@ func (p *Vault) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Vault) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	p.flushEscrowCleanup(ctw)
+	if err := p.flushEndedVestings(ctw, b.Header.Height); err != nil {
+		return err
+	}
+
 	LockedBalanceMap, err := p.flushLockedBalanceMap(ctw, b.Header.Height)
 	if err != nil {
 		return err
@@ -1,10 +1,13 @@
 package vault
 
 import (
+	"bytes"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/binutil"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
 )
 
 // Balance returns balance of the account of the address
@@ -231,3 +234,332 @@ func (p *Vault) GetDefaultFee(loader types.LoaderWrapper) *amount.Amount {
 	}
 	return amount.NewAmountFromBytes(bs)
 }
+
+// CheckVesting checks that the vesting schedule can be added at the target height
+func (p *Vault) CheckVesting(loader types.Loader, vs *VestingSchedule) error {
+	if vs.Count == 0 || vs.Count > MaxVestingCount || vs.Interval == 0 {
+		return ErrInvalidVestingSchedule
+	}
+	if vs.StartHeight < loader.TargetHeight() {
+		return ErrInvalidUnlockHeight
+	}
+	if uint64(vs.StartHeight)+uint64(vs.Interval)*uint64(vs.Count) > uint64(^uint32(0)) {
+		return ErrInvalidUnlockHeight
+	}
+	if vs.ReleaseAmount(0).Less(amount.COIN.DivC(10)) {
+		return types.ErrDustAmount
+	}
+	return nil
+}
+
+// AddVesting locks the amount of the schedule to releases and records the schedule to the recipient
+func (p *Vault) AddVesting(ctw *types.ContextWrapper, vs *VestingSchedule) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := p.CheckVesting(ctw, vs); err != nil {
+		return err
+	}
+	for i := uint32(0); i < vs.Count; i++ {
+		if err := p.AddLockedBalance(ctw, vs.To, vs.UnlockHeight(i), vs.ReleaseAmount(i)); err != nil {
+			return err
+		}
+	}
+	var Count uint32
+	if bs := ctw.AccountData(vs.To, tagVestingCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	if bs, err := encoding.Marshal(vs); err != nil {
+		return err
+	} else {
+		ctw.SetAccountData(vs.To, toVestingKey(Count), bs)
+	}
+	ctw.SetAccountData(vs.To, tagVestingCount, binutil.LittleEndian.Uint32ToBytes(Count+1))
+
+	EndHeight := vs.EndHeight()
+	if bs := ctw.ProcessData(toVestingEndKey(EndHeight, vs.To)); len(bs) == 0 {
+		var EndCount uint32
+		if bs := ctw.ProcessData(toVestingEndCountKey(EndHeight)); len(bs) > 0 {
+			EndCount = binutil.LittleEndian.Uint32(bs)
+		}
+		ctw.SetProcessData(toVestingEndKey(EndHeight, vs.To), []byte{1})
+		ctw.SetProcessData(toVestingEndReverseKey(EndHeight, EndCount), vs.To[:])
+		ctw.SetProcessData(toVestingEndCountKey(EndHeight), binutil.LittleEndian.Uint32ToBytes(EndCount+1))
+	}
+	return nil
+}
+
+// flushEndedVestings removes schedules that are fully released at the height from accounts of recipients
+func (p *Vault) flushEndedVestings(ctw *types.ContextWrapper, Height uint32) error {
+	bs := ctw.ProcessData(toVestingEndCountKey(Height))
+	if len(bs) == 0 {
+		return nil
+	}
+	EndCount := binutil.LittleEndian.Uint32(bs)
+	for i := uint32(0); i < EndCount; i++ {
+		var addr common.Address
+		copy(addr[:], ctw.ProcessData(toVestingEndReverseKey(Height, i)))
+
+		var Count uint32
+		if bs := ctw.AccountData(addr, tagVestingCount); len(bs) > 0 {
+			Count = binutil.LittleEndian.Uint32(bs)
+		}
+		for j := uint32(0); j < Count; {
+			vs := &VestingSchedule{}
+			if err := encoding.Unmarshal(ctw.AccountData(addr, toVestingKey(j)), &vs); err != nil {
+				return err
+			}
+			if vs.EndHeight() > Height {
+				j++
+				continue
+			}
+			Count--
+			if j != Count {
+				ctw.SetAccountData(addr, toVestingKey(j), ctw.AccountData(addr, toVestingKey(Count)))
+			}
+			ctw.SetAccountData(addr, toVestingKey(Count), nil)
+		}
+		if Count == 0 {
+			ctw.SetAccountData(addr, tagVestingCount, nil)
+		} else {
+			ctw.SetAccountData(addr, tagVestingCount, binutil.LittleEndian.Uint32ToBytes(Count))
+		}
+
+		ctw.SetProcessData(toVestingEndKey(Height, addr), nil)
+		ctw.SetProcessData(toVestingEndReverseKey(Height, i), nil)
+	}
+	ctw.SetProcessData(toVestingEndCountKey(Height), nil)
+	return nil
+}
+
+// VestingCount returns the number of vesting schedules that are not fully released to the account of the address
+func (p *Vault) VestingCount(loader types.Loader, addr common.Address) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(addr, tagVestingCount); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+// Vestings returns vesting schedules of the account of the address from the offset up to the limit
+func (p *Vault) Vestings(loader types.Loader, addr common.Address, Offset uint32, Limit uint32) ([]*VestingSchedule, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.VestingCount(lw, addr)
+	if Offset >= Count {
+		return []*VestingSchedule{}, nil
+	}
+	if Limit > Count-Offset {
+		Limit = Count - Offset
+	}
+	list := make([]*VestingSchedule, 0, Limit)
+	for i := Offset; i < Offset+Limit; i++ {
+		vs := &VestingSchedule{}
+		if err := encoding.Unmarshal(lw.AccountData(addr, toVestingKey(i)), &vs); err != nil {
+			return nil, err
+		}
+		list = append(list, vs)
+	}
+	return list, nil
+}
+
+// Escrow returns the escrow of the id
+func (p *Vault) Escrow(loader types.Loader, ID string) (*Escrow, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toEscrowKey(ID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistEscrow
+	}
+	es := &Escrow{}
+	if err := encoding.Unmarshal(bs, &es); err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// Escrows returns escrows that are sent or received by the account of the address
+func (p *Vault) Escrows(loader types.Loader, addr common.Address) ([]*Escrow, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	IDs := p.escrowIDs(lw, addr)
+	list := make([]*Escrow, 0, len(IDs))
+	for _, ID := range IDs {
+		es, err := p.Escrow(lw, ID)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, es)
+	}
+	return list, nil
+}
+
+// AddEscrow locks the amount of the sender to the escrow
+func (p *Vault) AddEscrow(ctw *types.ContextWrapper, es *Escrow) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := p.SubBalance(ctw, es.From, es.Amount); err != nil {
+		return err
+	}
+	if bs, err := encoding.Marshal(es); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(toEscrowKey(es.ID), bs)
+	}
+	p.addEscrowID(ctw, es.From, es.ID)
+	if es.To != es.From {
+		p.addEscrowID(ctw, es.To, es.ID)
+	}
+	return nil
+}
+
+// ReleaseEscrow removes the escrow and adds the amount to the account of the address
+func (p *Vault) ReleaseEscrow(ctw *types.ContextWrapper, es *Escrow, addr common.Address) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	ctw.SetProcessData(toEscrowKey(es.ID), nil)
+	p.removeEscrowID(ctw, es.From, es.ID)
+	if es.To != es.From {
+		p.removeEscrowID(ctw, es.To, es.ID)
+	}
+	if err := p.AddBalance(ctw, addr, es.Amount); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Vault) escrowCount(lw types.LoaderWrapper, addr common.Address) uint32 {
+	if bs := lw.AccountData(addr, tagEscrowCount); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+func (p *Vault) escrowIDs(lw types.LoaderWrapper, addr common.Address) []string {
+	Count := p.escrowCount(lw, addr)
+	IDs := make([]string, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		IDs = append(IDs, string(lw.AccountData(addr, toEscrowItemKey(i))))
+	}
+	return IDs
+}
+
+func (p *Vault) addEscrowID(ctw *types.ContextWrapper, addr common.Address, ID string) {
+	Count := p.escrowCount(ctw, addr)
+	ctw.SetAccountData(addr, toEscrowItemKey(Count), []byte(ID))
+	ctw.SetAccountData(addr, toEscrowNumberKey(ID), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetAccountData(addr, tagEscrowCount, binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+// removeEscrowID moves the last id to the position of the id
+// the last item and the zero count are kept until the end of the block because they can be set again by a later transaction of the block
+func (p *Vault) removeEscrowID(ctw *types.ContextWrapper, addr common.Address, ID string) {
+	Count := p.escrowCount(ctw, addr)
+	bs := ctw.AccountData(addr, toEscrowNumberKey(ID))
+	if Count == 0 || len(bs) == 0 {
+		return
+	}
+	num := binutil.LittleEndian.Uint32(bs)
+	if num != Count-1 {
+		LastID := string(ctw.AccountData(addr, toEscrowItemKey(Count-1)))
+		ctw.SetAccountData(addr, toEscrowItemKey(num), []byte(LastID))
+		ctw.SetAccountData(addr, toEscrowNumberKey(LastID), binutil.LittleEndian.Uint32ToBytes(num))
+	}
+	ctw.SetAccountData(addr, toEscrowNumberKey(ID), nil)
+	ctw.SetAccountData(addr, tagEscrowCount, binutil.LittleEndian.Uint32ToBytes(Count-1))
+
+	bs = ctw.ProcessData(tagEscrowCleanup)
+	for i := 0; i+common.AddressSize <= len(bs); i += common.AddressSize {
+		if bytes.Equal(bs[i:i+common.AddressSize], addr[:]) {
+			return
+		}
+	}
+	list := make([]byte, 0, len(bs)+common.AddressSize)
+	list = append(list, bs...)
+	list = append(list, addr[:]...)
+	ctw.SetProcessData(tagEscrowCleanup, list)
+}
+
+// flushEscrowCleanup deletes items after the count and the zero count of escrow lists that are shrunk in the block
+func (p *Vault) flushEscrowCleanup(ctw *types.ContextWrapper) {
+	bs := ctw.ProcessData(tagEscrowCleanup)
+	for i := 0; i+common.AddressSize <= len(bs); i += common.AddressSize {
+		var addr common.Address
+		copy(addr[:], bs[i:i+common.AddressSize])
+		Count := p.escrowCount(ctw, addr)
+		for j := Count; len(ctw.AccountData(addr, toEscrowItemKey(j))) > 0; j++ {
+			ctw.SetAccountData(addr, toEscrowItemKey(j), nil)
+		}
+		if Count == 0 {
+			ctw.SetAccountData(addr, tagEscrowCount, nil)
+		}
+	}
+	if len(bs) > 0 {
+		ctw.SetProcessData(tagEscrowCleanup, nil)
+	}
+}
+
+// RecoveryConfig returns the recovery config of the account of the address
+func (p *Vault) RecoveryConfig(loader types.Loader, addr common.Address) (*RecoveryConfig, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	var rc *RecoveryConfig
+	if bs := lw.AccountData(addr, tagRecoveryConfig); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &rc); err != nil {
+			return nil, err
+		}
+	}
+	if rc == nil {
+		return nil, ErrNotExistRecoveryConfig
+	}
+	return rc, nil
+}
+
+// SetRecoveryConfig updates the recovery config of the account of the address and drops the pending recovery
+// A nil config removes the recovery config
+func (p *Vault) SetRecoveryConfig(ctw *types.ContextWrapper, addr common.Address, rc *RecoveryConfig) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := p.SetRecovery(ctw, addr, nil); err != nil {
+		return err
+	}
+	if rc == nil {
+		ctw.SetAccountData(addr, tagRecoveryConfig, nil)
+	} else if bs, err := encoding.Marshal(rc); err != nil {
+		return err
+	} else {
+		ctw.SetAccountData(addr, tagRecoveryConfig, bs)
+	}
+	return nil
+}
+
+// Recovery returns the pending recovery of the account of the address
+func (p *Vault) Recovery(loader types.Loader, addr common.Address) (*Recovery, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	var rv *Recovery
+	if bs := lw.AccountData(addr, tagRecovery); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &rv); err != nil {
+			return nil, err
+		}
+	}
+	if rv == nil {
+		return nil, ErrNotExistRecovery
+	}
+	return rv, nil
+}
+
+// SetRecovery updates the pending recovery of the account of the address
+// A nil recovery removes the pending recovery
+func (p *Vault) SetRecovery(ctw *types.ContextWrapper, addr common.Address, rv *Recovery) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if rv == nil {
+		ctw.SetAccountData(addr, tagRecovery, nil)
+	} else if bs, err := encoding.Marshal(rv); err != nil {
+		return err
+	} else {
+		ctw.SetAccountData(addr, tagRecovery, bs)
+	}
+	return nil
+}
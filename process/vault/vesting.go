@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+)
+
+// MaxVestingCount is the maximum number of releases of a vesting schedule
+const MaxVestingCount = 256
+
+// MaxVestingQueryLimit is the maximum number of schedules that the vestings query returns at once
+const MaxVestingQueryLimit = 100
+
+// VestingSchedule releases the amount linearly by Count times at every Interval blocks after StartHeight
+// A time-locked transfer is a schedule that has one release
+type VestingSchedule struct {
+	From        common.Address
+	To          common.Address
+	Amount      *amount.Amount
+	StartHeight uint32
+	Interval    uint32
+	Count       uint32
+}
+
+// UnlockHeight returns the height of the i-th release
+func (vs *VestingSchedule) UnlockHeight(i uint32) uint32 {
+	return vs.StartHeight + vs.Interval*(i+1)
+}
+
+// EndHeight returns the height of the last release
+func (vs *VestingSchedule) EndHeight() uint32 {
+	return vs.UnlockHeight(vs.Count - 1)
+}
+
+// ReleaseAmount returns the amount of the i-th release
+// The last release includes the remainder of the division
+func (vs *VestingSchedule) ReleaseAmount(i uint32) *amount.Amount {
+	am := vs.Amount.DivC(int64(vs.Count))
+	if i == vs.Count-1 {
+		return vs.Amount.Sub(am.MulC(int64(vs.Count - 1)))
+	}
+	return am
+}
+
+// Released returns the released amount until the height
+func (vs *VestingSchedule) Released(Height uint32) *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for i := uint32(0); i < vs.Count; i++ {
+		if vs.UnlockHeight(i) > Height {
+			break
+		}
+		sum = sum.Add(vs.ReleaseAmount(i))
+	}
+	return sum
+}
+
+// MarshalJSON is a marshaler function
+func (vs *VestingSchedule) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"from":`)
+	if bs, err := vs.From.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := vs.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := vs.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"start_height":`)
+	if bs, err := json.Marshal(vs.StartHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"interval":`)
+	if bs, err := json.Marshal(vs.Interval); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"count":`)
+	if bs, err := json.Marshal(vs.Count); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"end_height":`)
+	if bs, err := json.Marshal(vs.EndHeight()); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// VestingStatus is the schedule and the released amount at the height of the query
+type VestingStatus struct {
+	Schedule *VestingSchedule
+	Released *amount.Amount
+}
+
+// MarshalJSON is a marshaler function
+func (vs *VestingStatus) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"schedule":`)
+	if bs, err := vs.Schedule.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"released":`)
+	if bs, err := vs.Released.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func TestVestingRemovedWhenReleased(t *testing.T) {
+	From := common.NewAddress(0, 1, 0)
+	To := common.NewAddress(0, 2, 0)
+	ctx := types.NewContext(&testLoader{accs: map[common.Address]types.Account{
+		From: &SingleAccount{Address_: From, KeyHash: common.PublicHash{1}},
+		To:   &SingleAccount{Address_: To, KeyHash: common.PublicHash{2}},
+	}})
+	p := NewVault(2)
+	ctw := types.NewContextWrapper(p.ID(), ctx)
+	for i := uint32(1); i <= 3; i++ {
+		vs := &VestingSchedule{
+			From:        From,
+			To:          To,
+			Amount:      amount.NewCoinAmount(1, 0),
+			StartHeight: ctx.TargetHeight(),
+			Interval:    i,
+			Count:       1,
+		}
+		if err := p.AddVesting(ctw, vs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if list, err := p.Vestings(ctw, To, 1, 10); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 2 || list[0].Interval != 2 {
+		t.Fatal("invalid vestings of the offset", list)
+	}
+
+	for ctx.TargetHeight() < 4 {
+		if err := p.AfterExecuteTransactions(&types.Block{Header: types.Header{Height: ctx.TargetHeight()}}, ctw); err != nil {
+			t.Fatal(err)
+		}
+		ctx = ctx.NextContext(hash.Hash256{}, 0)
+		ctw = types.NewContextWrapper(p.ID(), ctx)
+	}
+	if list, err := p.Vestings(ctw, To, 0, 10); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 || list[0].Interval != 3 {
+		t.Fatal("released vestings are not removed", list)
+	}
+	if !p.Balance(ctw, To).Equal(amount.NewCoinAmount(2, 0)) {
+		t.Fatal("invalid released balance", p.Balance(ctw, To).String())
+	}
+}
@@ -11,4 +11,5 @@ var (
 	ErrSelfConnection             = errors.New("self connection")
 	ErrInvalidUTXO                = errors.New("invalid UTXO")
 	ErrTooManyTrasactionInMessage = errors.New("too many transaction in message")
+	ErrClosedPeer                 = errors.New("closed peer")
 )
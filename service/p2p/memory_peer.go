@@ -0,0 +1,243 @@
+package p2p
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MemoryNetwork connects in-process peers and controls the delivery of packets between them
+type MemoryNetwork struct {
+	sync.Mutex
+	rand         *rand.Rand
+	delay        time.Duration
+	jitter       time.Duration
+	dropRate     float64
+	partitionMap map[string]int
+	linkDownMap  map[string]bool
+	sentCount    uint64
+	droppedCount uint64
+}
+
+// NewMemoryNetwork returns a MemoryNetwork which injects faults by the random seed
+func NewMemoryNetwork(seed int64) *MemoryNetwork {
+	return &MemoryNetwork{
+		rand:         rand.New(rand.NewSource(seed)),
+		partitionMap: map[string]int{},
+		linkDownMap:  map[string]bool{},
+	}
+}
+
+// SetDelay sets the base delay and the maximum additional random delay of the packet delivery
+func (n *MemoryNetwork) SetDelay(delay time.Duration, jitter time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.delay = delay
+	n.jitter = jitter
+}
+
+// SetDropRate sets the probability of dropping a packet (0 to 1)
+func (n *MemoryNetwork) SetDropRate(rate float64) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.dropRate = rate
+}
+
+// Partition splits nodes into the groups, packets between different groups are dropped
+// nodes that are not in any group are isolated from all partitioned nodes
+func (n *MemoryNetwork) Partition(groups ...[]string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.partitionMap = map[string]int{}
+	for i, group := range groups {
+		for _, name := range group {
+			n.partitionMap[name] = i + 1
+		}
+	}
+}
+
+// Heal removes all partitions and link failures
+func (n *MemoryNetwork) Heal() {
+	n.Lock()
+	defer n.Unlock()
+
+	n.partitionMap = map[string]int{}
+	n.linkDownMap = map[string]bool{}
+}
+
+// SetLinkDown drops packets between two nodes in both directions
+func (n *MemoryNetwork) SetLinkDown(A string, B string, IsDown bool) {
+	n.Lock()
+	defer n.Unlock()
+
+	if IsDown {
+		n.linkDownMap[A+"/"+B] = true
+		n.linkDownMap[B+"/"+A] = true
+	} else {
+		delete(n.linkDownMap, A+"/"+B)
+		delete(n.linkDownMap, B+"/"+A)
+	}
+}
+
+// Stats returns the number of sent packets and dropped packets
+func (n *MemoryNetwork) Stats() (uint64, uint64) {
+	n.Lock()
+	defer n.Unlock()
+
+	return n.sentCount, n.droppedCount
+}
+
+// Pipe connects two nodes and returns the peer of B owned by A and the peer of A owned by B
+func (n *MemoryNetwork) Pipe(AName string, AID string, BName string, BID string) (*MemoryPeer, *MemoryPeer) {
+	now := time.Now().UnixNano()
+	pa := &MemoryPeer{
+		net:           n,
+		id:            BID,
+		name:          BName,
+		from:          AName,
+		connectedTime: now,
+	}
+	pb := &MemoryPeer{
+		net:           n,
+		id:            AID,
+		name:          AName,
+		from:          BName,
+		connectedTime: now,
+	}
+	pa.cond = sync.NewCond(&pa.Mutex)
+	pb.cond = sync.NewCond(&pb.Mutex)
+	pa.remote = pb
+	pb.remote = pa
+	return pa, pb
+}
+
+func (n *MemoryNetwork) deliverAt(From string, To string) (int64, bool) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.sentCount++
+	if n.linkDownMap[From+"/"+To] || n.partitionMap[From] != n.partitionMap[To] {
+		n.droppedCount++
+		return 0, false
+	}
+	if n.dropRate > 0 && n.rand.Float64() < n.dropRate {
+		n.droppedCount++
+		return 0, false
+	}
+	delay := n.delay
+	if n.jitter > 0 {
+		delay += time.Duration(n.rand.Int63n(int64(n.jitter)))
+	}
+	return time.Now().UnixNano() + int64(delay), true
+}
+
+type memoryPacket struct {
+	data      []byte
+	deliverAt int64
+}
+
+// MemoryPeer is an in-process peer connected by the MemoryNetwork
+type MemoryPeer struct {
+	sync.Mutex
+	net           *MemoryNetwork
+	cond          *sync.Cond
+	remote        *MemoryPeer
+	id            string
+	name          string
+	from          string
+	inbox         []*memoryPacket
+	lastDeliverAt int64
+	isClose       bool
+	connectedTime int64
+}
+
+// ID returns the id of the peer
+func (p *MemoryPeer) ID() string {
+	return p.id
+}
+
+// Name returns the name of the peer
+func (p *MemoryPeer) Name() string {
+	return p.name
+}
+
+// Close closes both sides of the MemoryPeer
+func (p *MemoryPeer) Close() {
+	p.close()
+	p.remote.close()
+}
+
+func (p *MemoryPeer) close() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.isClose = true
+	p.cond.Broadcast()
+}
+
+// IsClosed returns it is closed or not
+func (p *MemoryPeer) IsClosed() bool {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.isClose
+}
+
+// ReadPacket returns a packet data when its delivery time is reached
+func (p *MemoryPeer) ReadPacket() ([]byte, error) {
+	p.Lock()
+	for len(p.inbox) == 0 && !p.isClose {
+		p.cond.Wait()
+	}
+	if p.isClose {
+		p.Unlock()
+		return nil, ErrClosedPeer
+	}
+	item := p.inbox[0]
+	p.inbox[0] = nil
+	p.inbox = p.inbox[1:]
+	p.Unlock()
+
+	if wait := item.deliverAt - time.Now().UnixNano(); wait > 0 {
+		time.Sleep(time.Duration(wait))
+	}
+	return item.data, nil
+}
+
+// SendPacket sends packet to the remote side of the MemoryPeer
+func (p *MemoryPeer) SendPacket(bs []byte) {
+	if p.IsClosed() {
+		return
+	}
+	at, ok := p.net.deliverAt(p.from, p.name)
+	if !ok {
+		return
+	}
+	data := make([]byte, len(bs))
+	copy(data, bs)
+	p.remote.push(data, at)
+}
+
+func (p *MemoryPeer) push(data []byte, at int64) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.isClose {
+		return
+	}
+	// packets of a connection are delivered in order like a stream
+	if at < p.lastDeliverAt {
+		at = p.lastDeliverAt
+	}
+	p.lastDeliverAt = at
+	p.inbox = append(p.inbox, &memoryPacket{data: data, deliverAt: at})
+	p.cond.Signal()
+}
+
+// ConnectedTime returns peer connected time
+func (p *MemoryPeer) ConnectedTime() int64 {
+	return p.connectedTime
+}
@@ -108,6 +108,9 @@ func (ms *NodeMesh) Run(BindAddress string) {
 			ms.Unlock()
 		}
 	}()
+	if len(BindAddress) == 0 {
+		return
+	}
 	if err := ms.server(BindAddress); err != nil {
 		panic(err)
 	}
@@ -3,6 +3,8 @@ package p2p
 import (
 	"sync"
 	"time"
+
+	"github.com/fletaio/fleta/common/clock"
 )
 
 // RequestExpireHandler handles a request expire event
@@ -16,14 +18,21 @@ type RequestTimer struct {
 	timerMap map[uint32]*requestTimerItem
 	valueMap map[string]map[uint32]bool
 	handler  RequestExpireHandler
+	clock    clock.Clock
 }
 
 // NewRequestTimer returns a RequestTimer
 func NewRequestTimer(handler RequestExpireHandler) *RequestTimer {
+	return NewRequestTimerWithClock(handler, clock.System)
+}
+
+// NewRequestTimerWithClock returns a RequestTimer that uses the clock
+func NewRequestTimerWithClock(handler RequestExpireHandler, c clock.Clock) *RequestTimer {
 	rm := &RequestTimer{
 		timerMap: map[uint32]*requestTimerItem{},
 		valueMap: map[string]map[uint32]bool{},
 		handler:  handler,
+		clock:    c,
 	}
 	return rm
 }
@@ -44,7 +53,7 @@ func (rm *RequestTimer) Add(height uint32, t time.Duration, value string) {
 
 	rm.timerMap[height] = &requestTimerItem{
 		Height:    height,
-		ExpiredAt: uint64(time.Now().UnixNano()) + uint64(t),
+		ExpiredAt: uint64(rm.clock.Now().UnixNano()) + uint64(t),
 		Value:     value,
 	}
 	heightMap, has := rm.valueMap[value]
@@ -73,7 +82,7 @@ func (rm *RequestTimer) RemovesByValue(value string) {
 func (rm *RequestTimer) Run() {
 	for {
 		expired := []*requestTimerItem{}
-		now := uint64(time.Now().UnixNano())
+		now := uint64(rm.clock.Now().UnixNano())
 		remainMap := map[uint32]*requestTimerItem{}
 		rm.Lock()
 		for h, v := range rm.timerMap {
@@ -99,7 +108,7 @@ func (rm *RequestTimer) Run() {
 				rm.handler.OnTimerExpired(v.Height, v.Value)
 			}
 		}
-		time.Sleep(200 * time.Millisecond)
+		rm.clock.Sleep(200 * time.Millisecond)
 	}
 }
 
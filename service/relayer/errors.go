@@ -0,0 +1,12 @@
+package relayer
+
+import "errors"
+
+// errors
+var (
+	ErrNotExistGateway          = errors.New("not exist gateway")
+	ErrExternalChainUnavailable = errors.New("external chain unavailable")
+	ErrInsufficientAttester     = errors.New("insufficient attester")
+	ErrRelayerClosed            = errors.New("relayer closed")
+	ErrInvalidHeight            = errors.New("invalid height")
+)
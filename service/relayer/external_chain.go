@@ -0,0 +1,35 @@
+package relayer
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+// Deposit is a confirmed deposit to the gateway on the external chain
+type Deposit struct {
+	Platform  string
+	ERC20TXID hash.Hash256
+	ERC20From gateway.ERC20Address
+	To        common.Address
+	Amount    *amount.Amount
+}
+
+// Payout is a withdrawal of the chain which should be paid out on the external chain
+type Payout struct {
+	Platform string
+	CoinTXID string
+	CoinFrom common.Address
+	To       gateway.ERC20Address
+	Amount   *amount.Amount
+}
+
+// ExternalChain is the interface to the external chain of the bridge
+type ExternalChain interface {
+	// Deposits returns confirmed deposits after the cursor and the next cursor
+	Deposits(Platform string, Cursor uint64) ([]*Deposit, uint64, error)
+	// SendPayout sends the payout and returns the txid of the external chain
+	// It should be idempotent by the CoinTXID of the payout
+	SendPayout(po *Payout) (hash.Hash256, error)
+}
@@ -0,0 +1,81 @@
+package relayer
+
+import (
+	"sync"
+
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// MemoryChain is an in-memory external chain for tests and local setups
+type MemoryChain struct {
+	sync.Mutex
+	depositMap map[string][]*Deposit
+	payoutMap  map[string]hash.Hash256
+	payouts    []*Payout
+	failCount  int
+}
+
+// NewMemoryChain returns a MemoryChain
+func NewMemoryChain() *MemoryChain {
+	return &MemoryChain{
+		depositMap: map[string][]*Deposit{},
+		payoutMap:  map[string]hash.Hash256{},
+	}
+}
+
+// AddDeposit appends the deposit to the platform
+func (mc *MemoryChain) AddDeposit(dp *Deposit) {
+	mc.Lock()
+	defer mc.Unlock()
+
+	mc.depositMap[dp.Platform] = append(mc.depositMap[dp.Platform], dp)
+}
+
+// FailPayouts makes next n payouts fail
+func (mc *MemoryChain) FailPayouts(n int) {
+	mc.Lock()
+	defer mc.Unlock()
+
+	mc.failCount = n
+}
+
+// Payouts returns payouts that are sent
+func (mc *MemoryChain) Payouts() []*Payout {
+	mc.Lock()
+	defer mc.Unlock()
+
+	list := make([]*Payout, len(mc.payouts))
+	copy(list, mc.payouts)
+	return list
+}
+
+// Deposits returns deposits after the cursor and the next cursor
+func (mc *MemoryChain) Deposits(Platform string, Cursor uint64) ([]*Deposit, uint64, error) {
+	mc.Lock()
+	defer mc.Unlock()
+
+	list := mc.depositMap[Platform]
+	if Cursor >= uint64(len(list)) {
+		return nil, Cursor, nil
+	}
+	return list[Cursor:], uint64(len(list)), nil
+}
+
+// SendPayout sends the payout once by the CoinTXID
+func (mc *MemoryChain) SendPayout(po *Payout) (hash.Hash256, error) {
+	mc.Lock()
+	defer mc.Unlock()
+
+	if TXID, has := mc.payoutMap[po.CoinTXID]; has {
+		return TXID, nil
+	}
+	if mc.failCount > 0 {
+		mc.failCount--
+		return hash.Hash256{}, ErrExternalChainUnavailable
+	}
+	TXID := encoding.Hash(po)
+	mc.payoutMap[po.CoinTXID] = TXID
+	mc.payouts = append(mc.payouts, po)
+	return TXID, nil
+}
@@ -0,0 +1,226 @@
+package relayer
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// record status
+const (
+	StatusPending = uint8(0)
+	StatusSent    = uint8(1)
+	StatusDone    = uint8(2)
+)
+
+// DepositRecord is the relay state of the deposit
+// It is pending until the TokenIn of the deposit is connected to the chain
+type DepositRecord struct {
+	Deposit     *Deposit
+	Status      uint8
+	Tries       uint32
+	SubmittedAt uint64
+	LastError   string
+}
+
+// PayoutRecord is the relay state of the payout
+// It is sent when the external chain accepts it and done when the TokenLeave of it is connected to the chain
+type PayoutRecord struct {
+	Payout      *Payout
+	Status      uint8
+	ERC20TXID   hash.Hash256
+	Tries       uint32
+	SubmittedAt uint64
+	LastError   string
+}
+
+// tags
+var (
+	tagHeight  = []byte{0, 0}
+	tagCursor  = []byte{1, 0}
+	tagDeposit = []byte{2, 0}
+	tagPayout  = []byte{3, 0}
+)
+
+func toCursorKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagCursor)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
+
+func toDepositKey(Platform string, ERC20TXID hash.Hash256) []byte {
+	bs := make([]byte, 2+hash.Hash256Size+len(Platform))
+	copy(bs, tagDeposit)
+	copy(bs[2:], ERC20TXID[:])
+	copy(bs[2+hash.Hash256Size:], []byte(Platform))
+	return bs
+}
+
+func toPayoutKey(Platform string, CoinTXID string) []byte {
+	bs := make([]byte, 2+len(CoinTXID)+len(Platform))
+	copy(bs, tagPayout)
+	copy(bs[2:], []byte(CoinTXID))
+	copy(bs[2+len(CoinTXID):], []byte(Platform))
+	return bs
+}
+
+// outbox persists relay states to the backend
+// All records are keyed by ids of the chains so that adding them again is ignored
+type outbox struct {
+	db backend.StoreBackend
+}
+
+func (ob *outbox) height() (uint32, bool, error) {
+	var Height uint32
+	if err := ob.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(tagHeight)
+		if err != nil {
+			return err
+		}
+		Height = binutil.LittleEndian.Uint32(value)
+		return nil
+	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return Height, true, nil
+}
+
+func (ob *outbox) cursor(Platform string) (uint64, error) {
+	var Cursor uint64
+	if err := ob.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(toCursorKey(Platform))
+		if err != nil {
+			return err
+		}
+		Cursor = binutil.LittleEndian.Uint64(value)
+		return nil
+	}); err != nil {
+		if err == backend.ErrNotExistKey {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return Cursor, nil
+}
+
+// addDeposits stores new deposits and the next cursor of the platform at once
+func (ob *outbox) addDeposits(Platform string, list []*Deposit, Cursor uint64) error {
+	return ob.db.Update(func(txn backend.StoreWriter) error {
+		for _, dp := range list {
+			if err := addRecord(txn, toDepositKey(dp.Platform, dp.ERC20TXID), &DepositRecord{Deposit: dp}); err != nil {
+				return err
+			}
+		}
+		return txn.Set(toCursorKey(Platform), binutil.LittleEndian.Uint64ToBytes(Cursor))
+	})
+}
+
+// applyBlock stores payouts and completions of the block with the height at once
+func (ob *outbox) applyBlock(Height uint32, payouts []*Payout, doneDeposits []*Deposit, donePayouts []*Payout) error {
+	return ob.db.Update(func(txn backend.StoreWriter) error {
+		for _, po := range payouts {
+			if err := addRecord(txn, toPayoutKey(po.Platform, po.CoinTXID), &PayoutRecord{Payout: po}); err != nil {
+				return err
+			}
+		}
+		for _, dp := range doneDeposits {
+			key := toDepositKey(dp.Platform, dp.ERC20TXID)
+			rec := &DepositRecord{}
+			if has, err := getRecord(txn, key, &rec); err != nil {
+				return err
+			} else if !has {
+				continue
+			}
+			rec.Status = StatusDone
+			if err := setRecord(txn, key, rec); err != nil {
+				return err
+			}
+		}
+		for _, po := range donePayouts {
+			key := toPayoutKey(po.Platform, po.CoinTXID)
+			rec := &PayoutRecord{}
+			if has, err := getRecord(txn, key, &rec); err != nil {
+				return err
+			} else if !has {
+				continue
+			}
+			rec.Status = StatusDone
+			if err := setRecord(txn, key, rec); err != nil {
+				return err
+			}
+		}
+		return txn.Set(tagHeight, binutil.LittleEndian.Uint32ToBytes(Height))
+	})
+}
+
+func (ob *outbox) deposits(fn func(rec *DepositRecord) error) error {
+	return ob.db.View(func(txn backend.StoreReader) error {
+		return txn.Iterate(tagDeposit, func(key []byte, value []byte) error {
+			rec := &DepositRecord{}
+			if err := encoding.Unmarshal(value, &rec); err != nil {
+				return err
+			}
+			return fn(rec)
+		})
+	})
+}
+
+func (ob *outbox) payouts(fn func(rec *PayoutRecord) error) error {
+	return ob.db.View(func(txn backend.StoreReader) error {
+		return txn.Iterate(tagPayout, func(key []byte, value []byte) error {
+			rec := &PayoutRecord{}
+			if err := encoding.Unmarshal(value, &rec); err != nil {
+				return err
+			}
+			return fn(rec)
+		})
+	})
+}
+
+func (ob *outbox) setDeposit(rec *DepositRecord) error {
+	return ob.db.Update(func(txn backend.StoreWriter) error {
+		return setRecord(txn, toDepositKey(rec.Deposit.Platform, rec.Deposit.ERC20TXID), rec)
+	})
+}
+
+func (ob *outbox) setPayout(rec *PayoutRecord) error {
+	return ob.db.Update(func(txn backend.StoreWriter) error {
+		return setRecord(txn, toPayoutKey(rec.Payout.Platform, rec.Payout.CoinTXID), rec)
+	})
+}
+
+func getRecord(txn backend.StoreReader, key []byte, v interface{}) (bool, error) {
+	value, err := txn.Get(key)
+	if err != nil {
+		if err == backend.ErrNotExistKey {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := encoding.Unmarshal(value, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func setRecord(txn backend.StoreWriter, key []byte, v interface{}) error {
+	bs, err := encoding.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return txn.Set(key, bs)
+}
+
+func addRecord(txn backend.StoreWriter, key []byte, v interface{}) error {
+	if _, err := txn.Get(key); err == nil {
+		return nil
+	} else if err != backend.ErrNotExistKey {
+		return err
+	}
+	return setRecord(txn, key, v)
+}
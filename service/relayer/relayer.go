@@ -0,0 +1,414 @@
+package relayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/rlog"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+// Config is the configuration of the relayer
+type Config struct {
+	Platforms      []string
+	RevokePlatform string
+	From           common.Address
+	ResubmitTime   time.Duration
+}
+
+// gatewayState answers processed ids of the gateway
+type gatewayState interface {
+	IsProcessedERC20TXID(Platform string, ERC20TXID hash.Hash256) (bool, error)
+	IsProcessedOutTXID(Platform string, CoinTXID string) (bool, error)
+}
+
+type chainGatewayState struct {
+	gw *gateway.Gateway
+	cn types.Provider
+}
+
+func (cs *chainGatewayState) IsProcessedERC20TXID(Platform string, ERC20TXID hash.Hash256) (bool, error) {
+	return cs.gw.IsProcessedERC20TXID(cs.cn.NewLoaderWrapper(cs.gw.ID()), Platform, ERC20TXID)
+}
+
+func (cs *chainGatewayState) IsProcessedOutTXID(Platform string, CoinTXID string) (bool, error) {
+	return cs.gw.IsProcessedOutTXID(cs.cn.NewLoaderWrapper(cs.gw.ID()), Platform, CoinTXID)
+}
+
+// Relayer relays deposits of the external chain to the gateway and pays out withdrawals of the chain on the external chain
+// Relay states are kept in the outbox so that the relayer retries them after restarts
+// Process runs under its own lock so that slow external calls do not block connecting blocks
+type Relayer struct {
+	types.ServiceBase
+	sync.Mutex
+	processLock sync.Mutex
+	cfg         *Config
+	ob          *outbox
+	ext         ExternalChain
+	att         Attester
+	sender      TxSender
+	cn          types.Provider
+	chainID     uint8
+	state       gatewayState
+	isClose     bool
+}
+
+// NewRelayer returns a Relayer
+func NewRelayer(db backend.StoreBackend, ext ExternalChain, att Attester, sender TxSender, cfg *Config) *Relayer {
+	if cfg.ResubmitTime == 0 {
+		cfg.ResubmitTime = 10 * time.Second
+	}
+	r := &Relayer{
+		cfg:    cfg,
+		ob:     &outbox{db: db},
+		ext:    ext,
+		att:    att,
+		sender: sender,
+	}
+	return r
+}
+
+// Name returns the name of the service
+func (r *Relayer) Name() string {
+	return "fleta.relayer"
+}
+
+// Init called when initialize service
+func (r *Relayer) Init(pm types.ProcessManager, cn types.Provider) error {
+	p, err := pm.ProcessByName("fleta.gateway")
+	if err != nil {
+		return ErrNotExistGateway
+	}
+	gw, is := p.(*gateway.Gateway)
+	if !is {
+		return types.ErrInvalidProcess
+	}
+	r.cn = cn
+	r.chainID = cn.ChainID()
+	r.state = &chainGatewayState{gw: gw, cn: cn}
+	return nil
+}
+
+// OnLoadChain called when the chain loaded
+// A new outbox starts from the current height and an existing outbox catches up blocks that are connected while stopped
+func (r *Relayer) OnLoadChain(loader types.Loader) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, has, err := r.ob.height(); err != nil {
+		return err
+	} else if !has {
+		return r.ob.applyBlock(r.cn.Height(), nil, nil, nil)
+	}
+	return r.catchUp(r.cn.Height())
+}
+
+// OnBlockConnected called when a block is connected to the chain
+// Blocks that are missed by failures are applied from the chain before the block
+func (r *Relayer) OnBlockConnected(b *types.Block, events []types.Event, loader types.Loader) {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.catchUp(b.Header.Height - 1); err != nil {
+		rlog.Println("relayer", "catch up", b.Header.Height, err)
+		return
+	}
+	if err := r.applyBlock(b, events); err != nil {
+		rlog.Println("relayer", "apply block", b.Header.Height, err)
+	}
+}
+
+// catchUp applies blocks of the chain after the height of the outbox until the target height
+func (r *Relayer) catchUp(TargetHeight uint32) error {
+	Height, has, err := r.ob.height()
+	if err != nil {
+		return err
+	}
+	if !has {
+		return nil
+	}
+	for h := Height + 1; h <= TargetHeight; h++ {
+		b, err := r.cn.Block(h)
+		if err != nil {
+			return err
+		}
+		events, err := r.cn.Events(h, h)
+		if err != nil {
+			return err
+		}
+		if err := r.applyBlock(b, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyBlock collects payouts and completed relays of the block
+// The block should be the next block of the outbox so that no payout is skipped
+func (r *Relayer) applyBlock(b *types.Block, events []types.Event) error {
+	if Height, has, err := r.ob.height(); err != nil {
+		return err
+	} else if has && b.Header.Height <= Height {
+		return nil
+	} else if has && b.Header.Height != Height+1 {
+		return ErrInvalidHeight
+	}
+
+	payouts := []*Payout{}
+	doneDeposits := []*Deposit{}
+	donePayouts := []*Payout{}
+	revokeIndexes := []uint16{}
+	for i, tx := range b.Transactions {
+		switch tx := tx.(type) {
+		case *gateway.TokenOut:
+			payouts = append(payouts, &Payout{
+				Platform: tx.Platform,
+				CoinTXID: types.TransactionID(b.Header.Height, uint16(i)),
+				CoinFrom: tx.From(),
+				To:       tx.ERC20To,
+				Amount:   tx.Amount,
+			})
+		case *gateway.TokenIn:
+			doneDeposits = append(doneDeposits, &Deposit{
+				Platform:  tx.Platform,
+				ERC20TXID: tx.ERC20TXID,
+			})
+		case *gateway.TokenLeave:
+			donePayouts = append(donePayouts, &Payout{
+				Platform: tx.Platform,
+				CoinTXID: tx.CoinTXID,
+			})
+		case *formulator.RevokeToBEP20:
+			revokeIndexes = append(revokeIndexes, uint16(i))
+		}
+	}
+	if len(r.cfg.RevokePlatform) > 0 {
+		// each RevokeToBEP20 emits one event in the order of transactions
+		var idx int
+		for _, e := range events {
+			ev, is := e.(*formulator.RevokeToBEP20Event)
+			if !is {
+				continue
+			}
+			if idx >= len(revokeIndexes) {
+				break
+			}
+			CoinTXID := types.TransactionID(b.Header.Height, revokeIndexes[idx])
+			idx++
+			To, err := gateway.ParseERC20Address(ev.BEP20Address)
+			if err != nil {
+				rlog.Println("relayer", "invalid bep20 address", CoinTXID, ev.BEP20Address)
+				continue
+			}
+			payouts = append(payouts, &Payout{
+				Platform: r.cfg.RevokePlatform,
+				CoinTXID: CoinTXID,
+				CoinFrom: ev.Heritor,
+				To:       To,
+				Amount:   ev.RevokeAmount,
+			})
+		}
+	}
+	return r.ob.applyBlock(b.Header.Height, payouts, doneDeposits, donePayouts)
+}
+
+// Run processes the outbox periodically until closed
+func (r *Relayer) Run(Interval time.Duration) {
+	for !r.isClosed() {
+		if err := r.Process(); err != nil {
+			rlog.Println("relayer", "process", err)
+		}
+		time.Sleep(Interval)
+	}
+}
+
+// Close stops the relayer
+func (r *Relayer) Close() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.isClose = true
+}
+
+func (r *Relayer) isClosed() bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.isClose
+}
+
+// Process polls deposits of the external chain and relays pending records once
+// Failures of each record are stored in the record and retried at the next call
+func (r *Relayer) Process() error {
+	r.processLock.Lock()
+	defer r.processLock.Unlock()
+
+	if r.isClosed() {
+		return ErrRelayerClosed
+	}
+	for _, Platform := range r.cfg.Platforms {
+		if err := r.pollDeposits(Platform); err != nil {
+			return err
+		}
+	}
+	if err := r.relayDeposits(); err != nil {
+		return err
+	}
+	if err := r.relayPayouts(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Relayer) pollDeposits(Platform string) error {
+	Cursor, err := r.ob.cursor(Platform)
+	if err != nil {
+		return err
+	}
+	list, Next, err := r.ext.Deposits(Platform, Cursor)
+	if err != nil {
+		rlog.Println("relayer", "poll deposits", Platform, err)
+		return nil
+	}
+	if len(list) == 0 && Next == Cursor {
+		return nil
+	}
+	return r.ob.addDeposits(Platform, list, Next)
+}
+
+func (r *Relayer) relayDeposits() error {
+	pending := []*DepositRecord{}
+	if err := r.ob.deposits(func(rec *DepositRecord) error {
+		if rec.Status != StatusDone {
+			pending = append(pending, rec)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	now := uint64(time.Now().UnixNano())
+	for _, rec := range pending {
+		dp := rec.Deposit
+		if is, err := r.state.IsProcessedERC20TXID(dp.Platform, dp.ERC20TXID); err != nil {
+			rec.LastError = err.Error()
+		} else if is {
+			rec.Status = StatusDone
+		} else if rec.SubmittedAt+uint64(r.cfg.ResubmitTime) <= now {
+			tx := &gateway.TokenIn{
+				Timestamp_:  now,
+				From_:       r.cfg.From,
+				Platform:    dp.Platform,
+				ERC20TXID:   dp.ERC20TXID,
+				ERC20From:   dp.ERC20From,
+				ToAddresses: []common.Address{dp.To},
+				Amounts:     []*amount.Amount{dp.Amount},
+			}
+			rec.Tries++
+			rec.SubmittedAt = now
+			if err := r.submit(tx, tx.AttestationHash(r.chainID), &tx.Attestations); err != nil {
+				rec.LastError = err.Error()
+			} else {
+				rec.LastError = ""
+			}
+		} else {
+			continue
+		}
+		if err := r.ob.setDeposit(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) relayPayouts() error {
+	pending := []*PayoutRecord{}
+	if err := r.ob.payouts(func(rec *PayoutRecord) error {
+		if rec.Status != StatusDone {
+			pending = append(pending, rec)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	now := uint64(time.Now().UnixNano())
+	for _, rec := range pending {
+		po := rec.Payout
+		if is, err := r.state.IsProcessedOutTXID(po.Platform, po.CoinTXID); err != nil {
+			rec.LastError = err.Error()
+		} else if is {
+			rec.Status = StatusDone
+		} else if rec.Status == StatusPending {
+			rec.Tries++
+			if ERC20TXID, err := r.ext.SendPayout(po); err != nil {
+				rec.LastError = err.Error()
+			} else {
+				rec.Status = StatusSent
+				rec.ERC20TXID = ERC20TXID
+				rec.LastError = ""
+			}
+		} else if rec.SubmittedAt+uint64(r.cfg.ResubmitTime) <= now {
+			tx := &gateway.TokenLeave{
+				Timestamp_: now,
+				From_:      r.cfg.From,
+				Platform:   po.Platform,
+				CoinTXID:   po.CoinTXID,
+				CoinFrom:   po.CoinFrom,
+				ERC20TXID:  rec.ERC20TXID,
+				ERC20To:    po.To,
+				Amount:     po.Amount,
+			}
+			rec.Tries++
+			rec.SubmittedAt = now
+			if err := r.submit(tx, tx.AttestationHash(r.chainID), &tx.Attestations); err != nil {
+				rec.LastError = err.Error()
+			} else {
+				rec.LastError = ""
+			}
+		} else {
+			continue
+		}
+		if err := r.ob.setPayout(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) submit(tx types.Transaction, h hash.Hash256, Attestations *[]common.Signature) error {
+	sigs, err := r.att.Attest(h)
+	if err != nil {
+		return err
+	}
+	*Attestations = sigs
+	return r.sender.SendTx(tx)
+}
+
+// Deposits returns relay states of all deposits
+func (r *Relayer) Deposits() ([]*DepositRecord, error) {
+	list := []*DepositRecord{}
+	if err := r.ob.deposits(func(rec *DepositRecord) error {
+		list = append(list, rec)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Payouts returns relay states of all payouts
+func (r *Relayer) Payouts() ([]*PayoutRecord, error) {
+	list := []*PayoutRecord{}
+	if err := r.ob.payouts(func(rec *PayoutRecord) error {
+		list = append(list, rec)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,228 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/backend/memory_driver"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+type testState struct {
+	erc20Map map[hash.Hash256]bool
+	outMap   map[string]bool
+}
+
+func (ts *testState) IsProcessedERC20TXID(Platform string, ERC20TXID hash.Hash256) (bool, error) {
+	return ts.erc20Map[ERC20TXID], nil
+}
+
+func (ts *testState) IsProcessedOutTXID(Platform string, CoinTXID string) (bool, error) {
+	return ts.outMap[CoinTXID], nil
+}
+
+type testSender struct {
+	txs []types.Transaction
+}
+
+func (ts *testSender) SendTx(tx types.Transaction) error {
+	ts.txs = append(ts.txs, tx)
+	return nil
+}
+
+type testProvider struct {
+	types.Provider
+	blocks map[uint32]*types.Block
+}
+
+func (tp *testProvider) Block(height uint32) (*types.Block, error) {
+	if b, has := tp.blocks[height]; has {
+		return b, nil
+	}
+	return nil, chain.ErrInvalidHeight
+}
+
+func (tp *testProvider) Events(From uint32, To uint32) ([]types.Event, error) {
+	return nil, nil
+}
+
+func TestRelayer(t *testing.T) {
+	path := "relayer_" + t.Name()
+	db, err := memory_driver.NewStoreBackendMemory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memory_driver.Remove(path)
+	k, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := &gateway.ValidatorSet{
+		PublicHashes: []common.PublicHash{common.NewPublicHash(k.PublicKey())},
+		Threshold:    1,
+		DailyCap:     amount.NewCoinAmount(0, 0),
+	}
+	mc := NewMemoryChain()
+	st := &testState{erc20Map: map[hash.Hash256]bool{}, outMap: map[string]bool{}}
+	sender := &testSender{}
+	newRelayer := func() *Relayer {
+		r := NewRelayer(db, mc, &KeyAttester{Keys: []key.Key{k}}, sender, &Config{
+			Platforms:    []string{"ETH"},
+			ResubmitTime: time.Hour,
+		})
+		r.chainID = 1
+		r.state = st
+		return r
+	}
+	r := newRelayer()
+
+	dp := &Deposit{
+		Platform:  "ETH",
+		ERC20TXID: hash.Hash([]byte("deposit")),
+		To:        common.NewAddress(1, 0, 0),
+		Amount:    amount.COIN,
+	}
+	mc.AddDeposit(dp)
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.txs) != 1 {
+		t.Fatal("invalid submitted count", len(sender.txs))
+	}
+	tin := sender.txs[0].(*gateway.TokenIn)
+	if tin.ERC20TXID != dp.ERC20TXID || tin.ToAddresses[0] != dp.To {
+		t.Fatal("invalid token in", tin)
+	}
+	if err := vs.CheckAttestations(tin.AttestationHash(1), tin.Attestations); err != nil {
+		t.Fatal(err)
+	}
+	st.erc20Map[dp.ERC20TXID] = true
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := r.Deposits(); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 || list[0].Status != StatusDone {
+		t.Fatal("deposit is not done", list)
+	}
+
+	b := &types.Block{
+		Header: types.Header{Height: 1},
+		Transactions: []types.Transaction{
+			&gateway.TokenOut{
+				Platform: "ETH",
+				ERC20To:  gateway.ERC20Address{1},
+				Amount:   amount.COIN,
+			},
+		},
+	}
+	r.OnBlockConnected(b, nil, nil)
+	r.OnBlockConnected(b, nil, nil)
+	CoinTXID := types.TransactionID(1, 0)
+
+	mc.FailPayouts(1)
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := r.Payouts(); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 || list[0].Status != StatusPending || len(list[0].LastError) == 0 {
+		t.Fatal("payout should be failed", list)
+	}
+
+	// the outbox is durable so that a new relayer continues the payout
+	r.Close()
+	r = newRelayer()
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mc.Payouts()) != 1 {
+		t.Fatal("invalid payout count", len(mc.Payouts()))
+	}
+	if len(sender.txs) != 2 {
+		t.Fatal("invalid submitted count", len(sender.txs))
+	}
+	tl := sender.txs[1].(*gateway.TokenLeave)
+	if tl.CoinTXID != CoinTXID || tl.ERC20To != (gateway.ERC20Address{1}) {
+		t.Fatal("invalid token leave", tl)
+	}
+
+	r.OnBlockConnected(&types.Block{
+		Header:       types.Header{Height: 2},
+		Transactions: []types.Transaction{tl},
+	}, nil, nil)
+	if list, err := r.Payouts(); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 || list[0].Status != StatusDone {
+		t.Fatal("payout is not done", list)
+	}
+}
+
+func TestRelayerCatchUp(t *testing.T) {
+	path := "relayer_" + t.Name()
+	db, err := memory_driver.NewStoreBackendMemory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memory_driver.Remove(path)
+
+	tp := &testProvider{blocks: map[uint32]*types.Block{}}
+	r := NewRelayer(db, NewMemoryChain(), &KeyAttester{}, &testSender{}, &Config{Platforms: []string{"ETH"}})
+	r.cn = tp
+
+	newBlock := func(Height uint32, Amount *amount.Amount) *types.Block {
+		b := &types.Block{Header: types.Header{Height: Height}}
+		if Amount != nil {
+			b.Transactions = []types.Transaction{
+				&gateway.TokenOut{Platform: "ETH", ERC20To: gateway.ERC20Address{byte(Height)}, Amount: Amount},
+			}
+		}
+		tp.blocks[Height] = b
+		return b
+	}
+	r.OnBlockConnected(newBlock(1, nil), nil, nil)
+
+	// the block 2 is missed and the block 3 is not applied until the block 2 is loaded from the chain
+	newBlock(2, amount.COIN)
+	delete(tp.blocks, 2)
+	r.OnBlockConnected(newBlock(3, amount.COIN.MulC(3)), nil, nil)
+	if Height, _, err := r.ob.height(); err != nil {
+		t.Fatal(err)
+	} else if Height != 1 {
+		t.Fatal("block is applied over the gap", Height)
+	}
+
+	newBlock(2, amount.COIN.MulC(2))
+	r.OnBlockConnected(newBlock(4, nil), nil, nil)
+	if Height, _, err := r.ob.height(); err != nil {
+		t.Fatal(err)
+	} else if Height != 4 {
+		t.Fatal("invalid height", Height)
+	}
+	list, err := r.Payouts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatal("invalid payout count", len(list))
+	}
+	CoinTXIDs := map[string]bool{}
+	for _, rec := range list {
+		CoinTXIDs[rec.Payout.CoinTXID] = true
+	}
+	if !CoinTXIDs[types.TransactionID(2, 0)] || !CoinTXIDs[types.TransactionID(3, 0)] {
+		t.Fatal("payouts of missed blocks are lost", CoinTXIDs)
+	}
+}
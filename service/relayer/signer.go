@@ -0,0 +1,57 @@
+package relayer
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Attester returns attestations of the bridge message hash
+// It can gather signatures of other validators in a multi operator setup
+type Attester interface {
+	Attest(h hash.Hash256) ([]common.Signature, error)
+}
+
+// KeyAttester attests with validator keys of the process
+type KeyAttester struct {
+	Keys []key.Key
+}
+
+// Attest signs the hash by all keys
+func (ka *KeyAttester) Attest(h hash.Hash256) ([]common.Signature, error) {
+	if len(ka.Keys) == 0 {
+		return nil, ErrInsufficientAttester
+	}
+	sigs := make([]common.Signature, 0, len(ka.Keys))
+	for _, k := range ka.Keys {
+		sig, err := k.Sign(h)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// TxSender signs and submits transactions of the relayer to the chain
+type TxSender interface {
+	SendTx(tx types.Transaction) error
+}
+
+// KeySender signs transactions with the key and passes them to the AddTx function of the node
+type KeySender struct {
+	ChainID uint8
+	Key     key.Key
+	AddTx   func(tx types.Transaction, sigs []common.Signature) error
+}
+
+// SendTx signs and submits the transaction
+func (ks *KeySender) SendTx(tx types.Transaction) error {
+	sig, err := ks.Key.Sign(chain.HashTransaction(ks.ChainID, tx))
+	if err != nil {
+		return err
+	}
+	return ks.AddTx(tx, []common.Signature{sig})
+}